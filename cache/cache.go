@@ -0,0 +1,111 @@
+/*
+ * Local cache for puzzle inputs
+ *
+ * Inputs are stored as <dir>/NN.txt, next to NN.json containing the ETag
+ * and Last-Modified header of the download, e.g. ~/.cache/aoc/2023/03.txt
+ * The directory can be changed via the environment variable AOC_CACHE_DIR
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Information about a cached input
+type Meta struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+}
+
+// Directory of the cache
+func Dir() (string, error) {
+	if dir := os.Getenv("AOC_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "2023"), nil
+}
+
+// Path of the cached input of a day (which may not exist)
+func Path(day int) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%02d.txt", day)), nil
+}
+
+func metaPath(day int) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%02d.json", day)), nil
+}
+
+// Load the cached input of a day - returns an error satisfying
+// os.IsNotExist if it is not cached
+func Load(day int) ([]byte, Meta, error) {
+	var meta Meta
+	fname, err := Path(day)
+	if err != nil {
+		return nil, meta, err
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		return nil, meta, err
+	}
+	mname, err := metaPath(day)
+	if err != nil {
+		return nil, meta, err
+	}
+	if buf, err := os.ReadFile(mname); err == nil {
+		json.Unmarshal(buf, &meta)
+	}
+	if meta.Fetched.IsZero() {
+		// no (valid) meta data, fall back to time of file
+		if fi, err := os.Stat(fname); err == nil {
+			meta.Fetched = fi.ModTime()
+		}
+	}
+	return data, meta, nil
+}
+
+// Store the input of a day and its meta data
+func Store(day int, data []byte, meta Meta) error {
+	fname, err := Path(day)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(fname, data, 0o644); err != nil {
+		return err
+	}
+	return Touch(day, meta)
+}
+
+// Update the meta data of a cached input only (e.g. after it was
+// revalidated with the server)
+func Touch(day int, meta Meta) error {
+	mname, err := metaPath(day)
+	if err != nil {
+		return err
+	}
+	buf, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(mname, buf, 0o644)
+}
@@ -0,0 +1,171 @@
+/*
+ * Cycle analysis
+ *
+ * Networks like the one of day 20 part 2 are built from independent
+ * counters: every output of the broadcaster starts a sub-circuit which
+ * sends a high pulse to one common conjunction in front of the target
+ * every n-th button press. Instead of assuming this, Analyze checks the
+ * structure, measures period and offset of every sub-circuit and reports
+ * when the network does not fit.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package circuit
+
+import (
+	"aoc23/tools"
+	"aoc23/tools/graph"
+	"errors"
+	"fmt"
+)
+
+// Returned (wrapped) if the network does not consist of independent counters
+var ErrPattern = errors.New("network does not fit the pattern")
+
+// Sub-circuit sending to the final conjunction: it sends a high pulse first
+// at press Offset and then every Period presses
+type Cycle struct {
+	Name    string   // module sending to the final conjunction
+	Modules []string // all modules of the sub-circuit
+	Offset  int
+	Period  int
+}
+
+type Analysis struct {
+	Target string // e.g. "rx"
+	Sender string // conjunction sending to the target
+	Cycles []Cycle
+}
+
+// number of high pulses needed to verify the period
+const samples = 3
+
+// Analyze the sub-circuits in front of target. The network is reset and
+// pressed until every sub-circuit repeated (at most maxPresses times).
+func Analyze(n *Network, target string, maxPresses int) (*Analysis, error) {
+	senders := n.Inputs(target)
+	if len(senders) != 1 {
+		return nil, fmt.Errorf("%w: %v modules send to %v, expected one", ErrPattern, len(senders), target)
+	}
+	a := &Analysis{Target: target, Sender: senders[0]}
+	if k, _ := n.Kind(a.Sender); k != Conjunction {
+		return nil, fmt.Errorf("%w: %v sends to %v, expected a conjunction", ErrPattern, a.Sender, target)
+	}
+	inputs := n.Inputs(a.Sender)
+	if len(inputs) < 2 {
+		return nil, fmt.Errorf("%w: only %v module sends to %v", ErrPattern, len(inputs), a.Sender)
+	}
+
+	// modules feeding an input must not feed any other input
+	owner := map[string]string{}
+	for _, in := range inputs {
+		modules := n.feeding(in, a.Sender)
+		for _, m := range modules {
+			if o, ok := owner[m]; ok {
+				return nil, fmt.Errorf("%w: %v feeds both %v and %v", ErrPattern, m, o, in)
+			}
+			owner[m] = in
+		}
+		a.Cycles = append(a.Cycles, Cycle{Name: in, Modules: modules})
+	}
+
+	// presses with a high pulse to the sender, per input
+	fired := map[string][]int{}
+	n.Reset()
+	remove := n.OnPulse(func(p Pulse, press int) {
+		if p.To != a.Sender || p.Level != High {
+			return
+		}
+		if f := fired[p.From]; len(f) < samples && (len(f) == 0 || f[len(f)-1] != press) {
+			fired[p.From] = append(f, press)
+		}
+	})
+	defer remove()
+
+	done := func() bool {
+		for _, in := range inputs {
+			if len(fired[in]) < samples {
+				return false
+			}
+		}
+		return true
+	}
+	for !done() && n.Presses() < maxPresses {
+		n.Press()
+	}
+	for _, in := range inputs {
+		if len(fired[in]) < samples {
+			return nil, fmt.Errorf("%w: %v sent %v high pulses in %v presses", ErrPattern, in, len(fired[in]), maxPresses)
+		}
+	}
+
+	for i := range a.Cycles {
+		c := &a.Cycles[i]
+		f := fired[c.Name]
+		c.Offset, c.Period = f[0], f[1]-f[0]
+		for j := 2; j < len(f); j++ {
+			if f[j]-f[j-1] != c.Period {
+				return nil, fmt.Errorf("%w: %v is not periodic, high at presses %v", ErrPattern, c.Name, f)
+			}
+		}
+	}
+	return a, nil
+}
+
+// Number of presses until all sub-circuits send high at the same press
+// (and the target gets a low pulse). If every sub-circuit fires first
+// after one period, this is just the LCM of the periods - else the
+// presses are combined via the Chinese remainder theorem.
+func (a *Analysis) Presses() (int, error) {
+	if len(a.Cycles) == 0 {
+		return 0, fmt.Errorf("%w: no cycles", ErrPattern)
+	}
+	offsets, periods := []int{}, []int{}
+	first, aligned := 0, true
+	for _, c := range a.Cycles {
+		offsets = append(offsets, c.Offset)
+		periods = append(periods, c.Period)
+		first = max(first, c.Offset)
+		aligned = aligned && c.Offset == c.Period
+	}
+	if aligned {
+		return tools.LCM(1, periods[0], periods[1:]...)
+	}
+
+	x, m, err := tools.CRT(offsets, periods)
+	if errors.Is(err, tools.ErrNoSolution) {
+		return 0, fmt.Errorf("%w: sub-circuits never send high at the same press", ErrPattern)
+	} else if err != nil {
+		return 0, err
+	}
+	// smallest solution not before the first pulse of every sub-circuit
+	if x < first {
+		x += (first - x + m - 1) / m * m
+	}
+	return x, nil
+}
+
+// internally used - modules with a path to the module, not passing the
+// sender or the broadcaster
+func (n *Network) feeding(name, sender string) []string {
+	dist := graph.Distances(name, func(node string) []graph.Edge[string] {
+		edges := []graph.Edge[string]{}
+		for _, in := range n.modules[node].inputs {
+			if in != sender && in != Start {
+				edges = append(edges, graph.Edge[string]{To: in, Cost: 1})
+			}
+		}
+		return edges
+	})
+	modules := []string{}
+	for _, m := range n.names {
+		if _, ok := dist[m]; ok {
+			modules = append(modules, m)
+		}
+	}
+	return modules
+}
+
+func (c Cycle) String() string {
+	return fmt.Sprintf("%v: offset %v, period %v (%v modules)", c.Name, c.Offset, c.Period, len(c.Modules))
+}
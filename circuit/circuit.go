@@ -0,0 +1,312 @@
+/*
+ * Pulse network
+ *
+ * Simulation of the modules of day 20: flip-flops ("%a -> b, c"),
+ * conjunctions ("&a -> b") and the broadcaster, connected by cables
+ * transporting low and high pulses. Instead of global counters, observers
+ * can be registered to watch every pulse, and every network counts the
+ * pulses sent per module - so several networks can run side by side.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package circuit
+
+import (
+	"aoc23/tools"
+	"fmt"
+	"io"
+)
+
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+func (l Level) String() string {
+	if l == High {
+		return "high"
+	}
+	return "low"
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = Low
+	case "high":
+		*l = High
+	default:
+		return fmt.Errorf("invalid level %q", text)
+	}
+	return nil
+}
+
+type Kind int
+
+const (
+	Output      Kind = iota // receives pulses only, e.g. "rx"
+	Broadcaster             // forwards every pulse
+	FlipFlop                // "%" - toggles on low pulses
+	Conjunction             // "&" - sends low if all inputs were high last
+)
+
+func (k Kind) String() string {
+	return [...]string{"output", "broadcaster", "flip-flop", "conjunction"}[k]
+}
+
+// Name of the module the button sends to, and of the button as sender
+const (
+	Start  = "broadcaster"
+	Button = "button"
+)
+
+type Pulse struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Level Level  `json:"level"`
+}
+
+func (p Pulse) String() string {
+	return fmt.Sprintf("%v -%v-> %v", p.From, p.Level, p.To)
+}
+
+// Number of pulses sent
+type Counter struct {
+	Low  int
+	High int
+}
+
+type module struct {
+	name    string
+	kind    Kind
+	outputs []string
+	inputs  []string
+	on      bool             // flip-flop state
+	memory  map[string]Level // last pulse per input of a conjunction
+}
+
+type Network struct {
+	modules   map[string]*module
+	names     []string // in the order of the input, outputs last
+	queue     tools.Queue[Pulse]
+	presses   int
+	counters  map[string]*Counter // per sender, incl. the button
+	observers []*observer
+}
+
+type observer struct {
+	f func(p Pulse, press int)
+}
+
+// one line of input, e.g. "%a -> inv, con"
+type moduleSpec struct {
+	Kind    string
+	Name    string
+	Outputs []string
+}
+
+// Create a network from its description, one module per line
+func Parse(lines []string) (*Network, error) {
+	specs, err := tools.ParseLines[moduleSpec](`^\s*(?P<kind>[%&]?)(?P<name>\w+) -> (?P<outputs>.*)$`, lines)
+	if err != nil {
+		return nil, err
+	}
+	n := &Network{modules: map[string]*module{}, counters: map[string]*Counter{}}
+	for i, spec := range specs {
+		if _, ok := n.modules[spec.Name]; ok {
+			return nil, &tools.LineError{Line: i + 1, Err: fmt.Errorf("module %q defined twice", spec.Name)}
+		}
+		var kind Kind
+		switch spec.Kind {
+		case "%":
+			kind = FlipFlop
+		case "&":
+			kind = Conjunction
+		default:
+			if spec.Name != Start {
+				return nil, &tools.LineError{Line: i + 1, Err: fmt.Errorf("module %q has no type (%% or &)", spec.Name)}
+			}
+			kind = Broadcaster
+		}
+		n.add(spec.Name, kind).outputs = spec.Outputs
+	}
+	// link inputs - modules only receiving are outputs
+	for _, name := range n.names {
+		for _, out := range n.modules[name].outputs {
+			m, ok := n.modules[out]
+			if !ok {
+				m = n.add(out, Output)
+			}
+			m.inputs = append(m.inputs, name)
+		}
+	}
+	if _, ok := n.modules[Start]; !ok {
+		return nil, fmt.Errorf("no module %q", Start)
+	}
+	n.Reset()
+	return n, nil
+}
+
+// Read the network from r
+func Read(r io.Reader) (*Network, error) {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(lines)
+}
+
+// Register f to be called for every pulse when it is delivered, with the
+// number of the current button press (starting with 1). The returned
+// function removes the observer again.
+func (n *Network) OnPulse(f func(p Pulse, press int)) func() {
+	o := &observer{f}
+	n.observers = append(n.observers, o)
+	return func() {
+		kept := []*observer{}
+		for _, other := range n.observers {
+			if other != o {
+				kept = append(kept, other)
+			}
+		}
+		n.observers = kept
+	}
+}
+
+// Push the button once and process all pulses until the network is stable
+func (n *Network) Press() {
+	n.presses++
+	n.send(Button, []string{Start}, Low)
+	for p, ok := n.queue.Pop(); ok; p, ok = n.queue.Pop() {
+		for _, o := range n.observers {
+			o.f(p, n.presses)
+		}
+		n.receive(p)
+	}
+}
+
+// Number of button presses since creation or the last reset
+func (n *Network) Presses() int {
+	return n.presses
+}
+
+// Pulses sent by the module (or the button) since the last reset
+func (n *Network) Sent(name string) Counter {
+	if c, ok := n.counters[name]; ok {
+		return *c
+	}
+	return Counter{}
+}
+
+// Pulses sent by all modules and the button since the last reset
+func (n *Network) Total() Counter {
+	total := Counter{}
+	for _, c := range n.counters {
+		total.Low += c.Low
+		total.High += c.High
+	}
+	return total
+}
+
+// Set all modules to their initial state, reset presses and counters -
+// observers are kept
+func (n *Network) Reset() {
+	for _, m := range n.modules {
+		m.on = false
+		m.memory = map[string]Level{}
+		for _, in := range m.inputs {
+			m.memory[in] = Low
+		}
+	}
+	n.presses = 0
+	n.queue.Clear()
+	clear(n.counters)
+}
+
+// Names of all modules, in the order of the input (modules only receiving last)
+func (n *Network) Names() []string {
+	return append([]string{}, n.names...)
+}
+
+func (n *Network) Kind(name string) (Kind, bool) {
+	m, ok := n.modules[name]
+	if !ok {
+		return Output, false
+	}
+	return m.kind, true
+}
+
+// Names of the modules sending to the module
+func (n *Network) Inputs(name string) []string {
+	if m, ok := n.modules[name]; ok {
+		return append([]string{}, m.inputs...)
+	}
+	return nil
+}
+
+// Names of the modules the module sends to
+func (n *Network) Outputs(name string) []string {
+	if m, ok := n.modules[name]; ok {
+		return append([]string{}, m.outputs...)
+	}
+	return nil
+}
+
+// internally used
+func (n *Network) add(name string, kind Kind) *module {
+	m := &module{name: name, kind: kind}
+	n.modules[name] = m
+	n.names = append(n.names, name)
+	return m
+}
+
+// internally used - queue pulses to all receivers
+func (n *Network) send(from string, to []string, level Level) {
+	c, ok := n.counters[from]
+	if !ok {
+		c = &Counter{}
+		n.counters[from] = c
+	}
+	for _, name := range to {
+		n.queue.Push(Pulse{from, name, level})
+		if level == High {
+			c.High++
+		} else {
+			c.Low++
+		}
+	}
+}
+
+// internally used
+func (n *Network) receive(p Pulse) {
+	m := n.modules[p.To]
+	switch m.kind {
+	case Broadcaster:
+		n.send(m.name, m.outputs, p.Level)
+	case FlipFlop:
+		if p.Level == Low {
+			m.on = !m.on
+			level := Low
+			if m.on {
+				level = High
+			}
+			n.send(m.name, m.outputs, level)
+		}
+	case Conjunction:
+		m.memory[p.From] = p.Level
+		level := Low
+		for _, l := range m.memory {
+			if l == Low {
+				level = High
+				break
+			}
+		}
+		n.send(m.name, m.outputs, level)
+	}
+}
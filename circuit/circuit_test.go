@@ -0,0 +1,294 @@
+package circuit
+
+import (
+	"aoc23/tools"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const example1 = `broadcaster -> a, b, c
+%a -> b
+%b -> c
+%c -> inv
+&inv -> a`
+
+const example2 = `broadcaster -> a
+%a -> inv, con
+&inv -> b
+%b -> con
+&con -> output`
+
+func read(t *testing.T, s string) *Network {
+	t.Helper()
+	n, err := Read(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return n
+}
+
+func TestParse(t *testing.T) {
+	n := read(t, example2)
+	if got := n.Names(); !slices.Equal(got, []string{"broadcaster", "a", "inv", "b", "con", "output"}) {
+		t.Errorf("got names %v", got)
+	}
+	for name, want := range map[string]Kind{"broadcaster": Broadcaster, "a": FlipFlop, "con": Conjunction, "output": Output} {
+		if k, ok := n.Kind(name); !ok || k != want {
+			t.Errorf("%v: got kind %v, want %v", name, k, want)
+		}
+	}
+	if got := n.Inputs("con"); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("got inputs %v", got)
+	}
+	if got := n.Outputs("a"); !slices.Equal(got, []string{"inv", "con"}) {
+		t.Errorf("got outputs %v", got)
+	}
+
+	for _, bad := range []string{"%a -> b\n%a -> c", "%a -> b", "broadcaster a", "broadcaster -> foo\nfoo -> x"} {
+		if _, err := Parse(strings.Split(bad, "\n")); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+	// only the broadcaster has no type
+	var le *tools.LineError
+	if _, err := Parse([]string{"broadcaster -> foo", "foo -> x"}); !errors.As(err, &le) || le.Line != 2 {
+		t.Errorf("got %v, want error in line 2", err)
+	}
+}
+
+func TestPress(t *testing.T) {
+	n := read(t, example1)
+	pulses := []string{}
+	n.OnPulse(func(p Pulse, press int) {
+		pulses = append(pulses, p.String())
+	})
+	n.Press()
+	want := []string{
+		"button -low-> broadcaster",
+		"broadcaster -low-> a",
+		"broadcaster -low-> b",
+		"broadcaster -low-> c",
+		"a -high-> b",
+		"b -high-> c",
+		"c -high-> inv",
+		"inv -low-> a",
+		"a -low-> b",
+		"b -low-> c",
+		"c -low-> inv",
+		"inv -high-> a",
+	}
+	if !slices.Equal(pulses, want) {
+		t.Errorf("got pulses\n%v\nwant\n%v", strings.Join(pulses, "\n"), strings.Join(want, "\n"))
+	}
+	if c := n.Total(); c.Low != 8 || c.High != 4 {
+		t.Errorf("got total %+v", c)
+	}
+	if c := n.Sent("broadcaster"); c.Low != 3 || c.High != 0 {
+		t.Errorf("got %+v sent by broadcaster", c)
+	}
+}
+
+// networks do not share any state
+func TestSideBySide(t *testing.T) {
+	n1, n2 := read(t, example1), read(t, example2)
+	for i := 0; i < 1000; i++ {
+		n1.Press()
+		n2.Press()
+	}
+	for _, tc := range []struct {
+		n    *Network
+		want int
+	}{{n1, 32000000}, {n2, 11687500}} {
+		c := tc.n.Total()
+		if c.Low*c.High != tc.want || tc.n.Presses() != 1000 {
+			t.Errorf("got %v after %v presses, want %v", c.Low*c.High, tc.n.Presses(), tc.want)
+		}
+	}
+
+	n2.Reset()
+	if n2.Presses() != 0 || n2.Total() != (Counter{}) {
+		t.Errorf("reset did not clear counters")
+	}
+	for i := 0; i < 1000; i++ {
+		n2.Press()
+	}
+	if c := n2.Total(); c.Low*c.High != 11687500 {
+		t.Errorf("got %v after reset", c.Low*c.High)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	n := read(t, example2)
+	var sb strings.Builder
+	if err := n.WriteDOT(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`"button" -> "broadcaster";`,
+		`"a" [label="%a", shape="box"];`,
+		`"con" [label="&con", shape="trapezium"];`,
+		`"output" [label="output", shape="doublecircle"];`,
+		`"a" -> "con" [label="2"];`,
+	} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("missing %q in\n%v", want, sb.String())
+		}
+	}
+}
+
+// two independent counters: ia sends high every 2nd, ib every 4th press
+const counters = `broadcaster -> a, b
+%a -> ia
+&ia -> s
+%b -> b2
+%b2 -> ib
+&ib -> s
+&s -> rx`
+
+func TestAnalyze(t *testing.T) {
+	n := read(t, counters)
+	a, err := Analyze(n, "rx", 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Sender != "s" || len(a.Cycles) != 2 {
+		t.Fatalf("got %+v", a)
+	}
+	if c := a.Cycles[0]; c.Offset != 2 || c.Period != 2 || !slices.Equal(c.Modules, []string{"a", "ia"}) {
+		t.Errorf("got cycle %v %v", c, c.Modules)
+	}
+	if c := a.Cycles[1]; c.Offset != 4 || c.Period != 4 || !slices.Equal(c.Modules, []string{"b", "b2", "ib"}) {
+		t.Errorf("got cycle %v %v", c, c.Modules)
+	}
+	presses, err := a.Presses()
+	if err != nil || presses != 4 {
+		t.Fatalf("got %v presses, %v", presses, err)
+	}
+
+	// check by simulation
+	n = read(t, counters)
+	low := 0
+	n.OnPulse(func(p Pulse, press int) {
+		if p.To == "rx" && p.Level == Low && low == 0 {
+			low = press
+		}
+	})
+	for low == 0 && n.Presses() < 100 {
+		n.Press()
+	}
+	if low != presses {
+		t.Errorf("rx got low pulse at press %v, analysis says %v", low, presses)
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{"no sender", example2},
+		{"no conjunction", "broadcaster -> a\n%a -> rx"},
+		{"shared module", strings.Replace(counters, "%a -> ia", "%a -> ia, b2", 1)},
+		{"never together", strings.Replace(counters, "&ia -> s", "%ia -> s", 1)},
+		{"too few presses", strings.Replace(counters, "%b2 -> ib", "%b2 -> b3\n%b3 -> b4\n%b4 -> b5\n%b5 -> ib", 1)},
+	} {
+		n := read(t, tc.input)
+		a, err := Analyze(n, "rx", 40)
+		if err == nil {
+			_, err = a.Presses()
+		}
+		if !errors.Is(err, ErrPattern) {
+			t.Errorf("%v: got %v, want ErrPattern", tc.name, err)
+		}
+	}
+}
+
+func TestPressesCRT(t *testing.T) {
+	a := &Analysis{Cycles: []Cycle{{Offset: 1, Period: 2}, {Offset: 3, Period: 5}, {Offset: 5, Period: 6}}}
+	// x ≡ 1 (mod 2), x ≡ 3 (mod 5), x ≡ 5 (mod 6)
+	if got, err := a.Presses(); err != nil || got != 23 {
+		t.Errorf("got %v, %v, want 23", got, err)
+	}
+	a = &Analysis{Cycles: []Cycle{{Offset: 10, Period: 4}, {Offset: 3, Period: 2}}}
+	// x ≡ 2 (mod 4), x ≡ 1 (mod 2) has no solution
+	if _, err := a.Presses(); !errors.Is(err, ErrPattern) {
+		t.Errorf("got %v, want ErrPattern", err)
+	}
+	a = &Analysis{Cycles: []Cycle{{Offset: 11, Period: 3}, {Offset: 2, Period: 4}}}
+	// x ≡ 2 (mod 12), but not before press 11
+	if got, err := a.Presses(); err != nil || got != 14 {
+		t.Errorf("got %v, %v, want 14", got, err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	n := read(t, example2)
+	initial := n.Snapshot()
+	if initial != "00000" { // a, inv (from a), b, con (from a and b)
+		t.Errorf("got initial state %q", initial)
+	}
+	n.Press()
+	after1 := n.Snapshot()
+	n.Press()
+	if err := n.Restore(after1); err != nil || n.Snapshot() != after1 {
+		t.Errorf("restore: got %q, %v", n.Snapshot(), err)
+	}
+	for _, bad := range []State{"01", "01x0"} {
+		if err := n.Restore(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+
+	// cycle detection on the whole network
+	step := func(s State) State {
+		n.Restore(s)
+		n.Press()
+		return n.Snapshot()
+	}
+	if mu, lambda := tools.FindCycleKey(initial, step, func(s State) State { return s }); mu != 0 || lambda != 4 {
+		t.Errorf("got cycle %v, %v", mu, lambda)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	n := read(t, example1)
+	tr := Record(n)
+	n.Press()
+	n.Press()
+	tr.Stop()
+	n.Press()
+	if len(tr.Events) != 24 || tr.Presses() != 2 {
+		t.Fatalf("got %v events for %v presses", len(tr.Events), tr.Presses())
+	}
+
+	var sb strings.Builder
+	if err := tr.Write(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line, _, _ := strings.Cut(sb.String(), "\n"); line != `{"press":1,"from":"button","to":"broadcaster","level":"low"}` {
+		t.Errorf("got first line %v", line)
+	}
+	loaded, err := ReadTrace(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if i, diff := loaded.Diff(tr); diff {
+		t.Errorf("traces differ at %v", i)
+	}
+	if err := Replay(n, loaded); err != nil {
+		t.Errorf("replay: %v", err)
+	}
+
+	loaded.Events[1].Level = High
+	if i, diff := loaded.Diff(tr); !diff || i != 1 {
+		t.Errorf("got diff at %v, %v", i, diff)
+	}
+	if err := Replay(n, loaded); err == nil || !strings.Contains(err.Error(), "pulse 2 differs") {
+		t.Errorf("replay of modified trace: got %v", err)
+	}
+	if _, err := ReadTrace(strings.NewReader(`{"press":1,"level":"medium"}`)); err == nil {
+		t.Errorf("expected error for invalid level")
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * DOT export of a network
+ *
+ * Flip-flops are boxes, conjunctions trapeziums, the broadcaster a diamond
+ * and pure outputs double circles. Edges are labelled with the position in
+ * the output list of the sender, i.e. the order in which pulses are sent.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package circuit
+
+import (
+	"aoc23/tools/dot"
+	"fmt"
+	"io"
+)
+
+var shapes = map[Kind]string{
+	Output:      "doublecircle",
+	Broadcaster: "diamond",
+	FlipFlop:    "box",
+	Conjunction: "trapezium",
+}
+
+var prefixes = map[Kind]string{
+	FlipFlop:    "%",
+	Conjunction: "&",
+}
+
+// The module graph, incl. the button
+func (n *Network) Graph() *dot.Graph {
+	g := &dot.Graph{Name: "network"}
+	g.AddNode(dot.Node{ID: Button, Shape: "circle"})
+	g.AddEdge(Button, Start, "")
+	for _, name := range n.names {
+		m := n.modules[name]
+		g.AddNode(dot.Node{ID: name, Label: prefixes[m.kind] + name, Shape: shapes[m.kind]})
+		for i, out := range m.outputs {
+			g.AddEdge(name, out, fmt.Sprint(i+1))
+		}
+	}
+	return g
+}
+
+// Write the module graph in DOT format
+func (n *Network) WriteDOT(w io.Writer) error {
+	return n.Graph().Write(w)
+}
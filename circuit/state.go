@@ -0,0 +1,74 @@
+/*
+ * Network state
+ *
+ * The state of a network is given by the flip-flop bits and the memories
+ * of the conjunctions. A snapshot is a plain string, so it can be compared,
+ * used as map key and thus for cycle detection on the whole network.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package circuit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// One '0' or '1' per flip-flop and per input of a conjunction, in the
+// order of the modules
+type State string
+
+// Current state of all modules - presses and counters are not included
+func (n *Network) Snapshot() State {
+	var sb strings.Builder
+	for _, name := range n.names {
+		m := n.modules[name]
+		switch m.kind {
+		case FlipFlop:
+			sb.WriteByte(bit(m.on))
+		case Conjunction:
+			for _, in := range m.inputs {
+				sb.WriteByte(bit(m.memory[in] == High))
+			}
+		}
+	}
+	return State(sb.String())
+}
+
+// Set all modules to a state taken from a network with the same modules -
+// presses, counters and observers are kept
+func (n *Network) Restore(s State) error {
+	if want := len(n.Snapshot()); len(s) != want {
+		return fmt.Errorf("state of length %v does not fit the network (%v)", len(s), want)
+	}
+	if i := strings.IndexFunc(string(s), func(r rune) bool { return r != '0' && r != '1' }); i >= 0 {
+		return fmt.Errorf("invalid state %q at %v", s[i], i)
+	}
+	i := 0
+	for _, name := range n.names {
+		m := n.modules[name]
+		switch m.kind {
+		case FlipFlop:
+			m.on = s[i] == '1'
+			i++
+		case Conjunction:
+			for _, in := range m.inputs {
+				m.memory[in] = Low
+				if s[i] == '1' {
+					m.memory[in] = High
+				}
+				i++
+			}
+		}
+	}
+	n.queue.Clear()
+	return nil
+}
+
+// internally used
+func bit(b bool) byte {
+	if b {
+		return '1'
+	}
+	return '0'
+}
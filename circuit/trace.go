@@ -0,0 +1,124 @@
+/*
+ * Pulse traces
+ *
+ * A trace records every pulse delivered in a network, together with the
+ * button press. Traces are written and read as JSON lines, e.g.
+ *
+ *	{"press":1,"from":"button","to":"broadcaster","level":"low"}
+ *
+ * and can be replayed against a network or compared with another trace.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package circuit
+
+import (
+	"aoc23/tools"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type Event struct {
+	Press int `json:"press"`
+	Pulse
+}
+
+type Trace struct {
+	Events []Event
+	stop   func()
+}
+
+// Start recording all pulses of the network until Stop is called
+func Record(n *Network) *Trace {
+	t := &Trace{}
+	t.stop = n.OnPulse(func(p Pulse, press int) {
+		t.Events = append(t.Events, Event{press, p})
+	})
+	return t
+}
+
+// Stop recording
+func (t *Trace) Stop() {
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+}
+
+// Number of button presses covered by the trace
+func (t *Trace) Presses() int {
+	if len(t.Events) == 0 {
+		return 0
+	}
+	return t.Events[len(t.Events)-1].Press
+}
+
+// Write the trace as JSON lines, one event per line
+func (t *Trace) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, e := range t.Events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read a trace written by Write - empty lines are ignored
+func ReadTrace(r io.Reader) (*Trace, error) {
+	lines, done := tools.Lines(r)
+	t := &Trace{}
+	i := 0
+	for line := range lines {
+		i++
+		if line == "" {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, &tools.LineError{Line: i, Err: err}
+		}
+		t.Events = append(t.Events, e)
+	}
+	if err := done(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Index of the first event that differs from other (or is missing in one
+// of them), false if both traces are equal
+func (t *Trace) Diff(other *Trace) (int, bool) {
+	for i := range min(len(t.Events), len(other.Events)) {
+		if t.Events[i] != other.Events[i] {
+			return i, true
+		}
+	}
+	if len(t.Events) != len(other.Events) {
+		return min(len(t.Events), len(other.Events)), true
+	}
+	return 0, false
+}
+
+// Reset the network, press the button as often as recorded and check
+// that it sends the same pulses
+func Replay(n *Network, t *Trace) error {
+	n.Reset()
+	got := Record(n)
+	defer got.Stop()
+	for n.Presses() < t.Presses() {
+		n.Press()
+	}
+	i, diff := got.Diff(t)
+	switch {
+	case !diff:
+		return nil
+	case i >= len(got.Events):
+		return fmt.Errorf("missing pulse %v: %v (press %v)", i+1, t.Events[i].Pulse, t.Events[i].Press)
+	case i >= len(t.Events):
+		return fmt.Errorf("unexpected pulse %v: %v (press %v)", i+1, got.Events[i].Pulse, got.Events[i].Press)
+	}
+	return fmt.Errorf("pulse %v differs: got %v (press %v), want %v (press %v)",
+		i+1, got.Events[i].Pulse, got.Events[i].Press, t.Events[i].Pulse, t.Events[i].Press)
+}
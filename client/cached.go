@@ -0,0 +1,49 @@
+/*
+ * Cached access to the puzzle input
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package client
+
+import (
+	"aoc23/cache"
+	"os"
+	"path/filepath"
+)
+
+// Use the cache directory to track the time of the last request
+func (c *Client) UseCacheStamp() error {
+	dir, err := cache.Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	c.StampFile = filepath.Join(dir, "last-request")
+	return nil
+}
+
+// Return the input of a day from the local cache. Only if it is not cached
+// yet (or refresh is set) the server is asked - for a refresh the request
+// is conditional, using ETag and Last-Modified of the cached input
+func (c *Client) CachedInput(day int, refresh bool) ([]byte, error) {
+	data, meta, err := cache.Load(day)
+	if err == nil && !refresh {
+		return data, nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	dl, err := c.FetchInput(day, meta.ETag, meta.LastModified)
+	if err != nil {
+		return nil, err
+	}
+	meta.Fetched = c.clock()
+	if dl.NotModified {
+		return data, cache.Touch(day, meta)
+	}
+	meta.ETag = dl.ETag
+	meta.LastModified = dl.LastModified
+	return dl.Data, cache.Store(day, dl.Data, meta)
+}
@@ -0,0 +1,221 @@
+/*
+ * Client for the Advent of Code website
+ *
+ * Downloads the puzzle input using the session cookie. The base URL
+ * can be changed, e.g. to test against a local server. Requests are
+ * throttled to keep some minimum interval in between
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL     = "https://adventofcode.com"
+	DefaultMinInterval = 5 * time.Second
+	Year               = 2023
+	userAgent          = "github.com/jrathert/AoC2023"
+)
+
+var (
+	// ErrNoSession is returned if no session token can be found
+	ErrNoSession = errors.New("no session token available")
+	// ErrNotUnlocked is returned for puzzles that are not available yet
+	ErrNotUnlocked = errors.New("puzzle not unlocked yet")
+)
+
+// puzzles unlock at midnight EST
+var est = time.FixedZone("EST", -5*60*60)
+
+type Client struct {
+	BaseURL     string
+	Session     string
+	HTTPClient  *http.Client
+	MinInterval time.Duration // minimum time between two requests
+	StampFile   string        // optional, its mtime tracks the last request across runs
+
+	last  time.Time
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// Result of a download
+type Download struct {
+	Data         []byte
+	ETag         string
+	LastModified string
+	NotModified  bool // conditional request, data did not change
+}
+
+// Create a client for the AoC website using the given session token
+func New(session string) *Client {
+	return &Client{
+		BaseURL:     DefaultBaseURL,
+		Session:     session,
+		HTTPClient:  http.DefaultClient,
+		MinInterval: DefaultMinInterval,
+	}
+}
+
+// Time the puzzle of the given day unlocks
+func UnlockTime(day int) time.Time {
+	return time.Date(Year, time.December, day, 0, 0, 0, 0, est)
+}
+
+// Download the input of the given day
+func (c *Client) Input(day int) ([]byte, error) {
+	dl, err := c.FetchInput(day, "", "")
+	return dl.Data, err
+}
+
+// Download the input of the given day. If etag or lastModified (of an
+// earlier download) are given, the request is conditional and the result
+// is marked NotModified if the input did not change
+func (c *Client) FetchInput(day int, etag, lastModified string) (Download, error) {
+	var dl Download
+	if err := c.checkUnlocked(day); err != nil {
+		return dl, err
+	}
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("/%v/day/%v/input", Year, day), nil)
+	if err != nil {
+		return dl, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return dl, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return dl, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		dl.Data = body
+	case http.StatusNotModified:
+		dl.NotModified = true
+	case http.StatusNotFound:
+		// AoC answers 404 for puzzles that are not available (yet)
+		return dl, fmt.Errorf("%w: day %02d: %v", ErrNotUnlocked, day, strings.TrimSpace(string(body)))
+	default:
+		return dl, fmt.Errorf("downloading input of day %02d: %v: %v", day, resp.Status, strings.TrimSpace(string(body)))
+	}
+	dl.ETag = resp.Header.Get("ETag")
+	dl.LastModified = resp.Header.Get("Last-Modified")
+	return dl, nil
+}
+
+func (c *Client) checkUnlocked(day int) error {
+	unlock := UnlockTime(day)
+	if now := c.clock(); now.Before(unlock) {
+		return fmt.Errorf("%w: day %02d unlocks at %v (in %v)", ErrNotUnlocked, day,
+			unlock.UTC().Format("2006-01-02 15:04 MST"), unlock.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	url := strings.TrimSuffix(c.BaseURL, "/") + path
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	return req, nil
+}
+
+// send a request, after waiting for the minimum interval to pass
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	c.throttle()
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return hc.Do(req)
+}
+
+func (c *Client) throttle() {
+	last := c.last
+	if c.StampFile != "" {
+		if fi, err := os.Stat(c.StampFile); err == nil && fi.ModTime().After(last) {
+			last = fi.ModTime()
+		}
+	}
+	if !last.IsZero() {
+		if wait := c.MinInterval - c.clock().Sub(last); wait > 0 {
+			c.wait(wait)
+		}
+	}
+	c.last = c.clock()
+	if c.StampFile != "" {
+		if err := os.Chtimes(c.StampFile, c.last, c.last); os.IsNotExist(err) {
+			os.WriteFile(c.StampFile, nil, 0o644)
+			os.Chtimes(c.StampFile, c.last, c.last)
+		}
+	}
+}
+
+func (c *Client) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func (c *Client) wait(d time.Duration) {
+	if c.sleep != nil {
+		c.sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+// Read the session token - the environment variable AOC_SESSION wins,
+// else it is read from the given file (usually ".env") with a line like
+//
+//	sessiontoken=abcdefgh12345678...
+func LoadSession(fname string) (string, error) {
+	if s := os.Getenv("AOC_SESSION"); s != "" {
+		return s, nil
+	}
+	file, err := os.Open(fname)
+	if os.IsNotExist(err) {
+		return "", ErrNoSession
+	} else if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), "=")
+		if ok && strings.TrimSpace(key) == "sessiontoken" && strings.TrimSpace(val) != "" {
+			return strings.TrimSpace(val), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", ErrNoSession
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// stand-in for the AoC website, counting the requests for inputs
+func newServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2023/day/{day}/input", func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.PathValue("day") != "3" {
+			http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("467..114..\n...*......\n"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(srv *httptest.Server) *Client {
+	c := New("secret")
+	c.BaseURL = srv.URL
+	c.MinInterval = 0
+	return c
+}
+
+func TestInput(t *testing.T) {
+	var requests int
+	srv := newServer(t, &requests)
+
+	c := newTestClient(srv)
+	data, err := c.Input(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "467..114..\n...*......\n" {
+		t.Errorf("got %q", data)
+	}
+
+	if _, err := c.Input(4); !errors.Is(err, ErrNotUnlocked) {
+		t.Errorf("got %v, want ErrNotUnlocked", err)
+	}
+
+	c.Session = "wrong"
+	if _, err := c.Input(3); err == nil {
+		t.Error("expected error for wrong session")
+	}
+
+	c.Session = ""
+	if _, err := c.Input(3); !errors.Is(err, ErrNoSession) {
+		t.Errorf("got %v, want ErrNoSession", err)
+	}
+}
+
+func TestCachedInput(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	var requests int
+	srv := newServer(t, &requests)
+	c := newTestClient(srv)
+
+	for i := 0; i < 2; i++ {
+		data, err := c.CachedInput(3, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != "467..114..\n...*......\n" {
+			t.Errorf("got %q", data)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %v requests, want 1 (second one from cache)", requests)
+	}
+
+	// refresh is conditional, server answers "not modified"
+	data, err := c.CachedInput(3, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 || string(data) != "467..114..\n...*......\n" {
+		t.Errorf("got %v requests and %q", requests, data)
+	}
+}
+
+func TestNotUnlocked(t *testing.T) {
+	var requests int
+	srv := newServer(t, &requests)
+	c := newTestClient(srv)
+	c.now = func() time.Time { return time.Date(2023, time.December, 3, 4, 59, 0, 0, time.UTC) }
+
+	_, err := c.Input(3)
+	if !errors.Is(err, ErrNotUnlocked) {
+		t.Errorf("got %v, want ErrNotUnlocked", err)
+	}
+	if requests != 0 {
+		t.Errorf("got %v requests, want none", requests)
+	}
+
+	c.now = func() time.Time { return time.Date(2023, time.December, 3, 5, 0, 0, 0, time.UTC) }
+	if _, err := c.Input(3); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	var requests int
+	srv := newServer(t, &requests)
+	c := newTestClient(srv)
+	c.MinInterval = 5 * time.Second
+	c.StampFile = t.TempDir() + "/last-request"
+
+	now := time.Date(2023, time.December, 24, 12, 0, 0, 0, time.UTC)
+	var waited []time.Duration
+	c.now = func() time.Time { return now }
+	c.sleep = func(d time.Duration) {
+		waited = append(waited, d)
+		now = now.Add(d)
+	}
+
+	c.Input(3)
+	now = now.Add(2 * time.Second)
+	c.Input(3)
+	if len(waited) != 1 || waited[0] != 3*time.Second {
+		t.Errorf("waited %v, want [3s]", waited)
+	}
+
+	// a new client (i.e. the next run) knows about the last request
+	c2 := newTestClient(srv)
+	c2.MinInterval, c2.StampFile, c2.now, c2.sleep = c.MinInterval, c.StampFile, c.now, c.sleep
+	now = now.Add(time.Second)
+	c2.Input(3)
+	if len(waited) != 2 || waited[1] != 4*time.Second {
+		t.Errorf("waited %v, want [3s 4s]", waited)
+	}
+}
+
+func TestLoadSession(t *testing.T) {
+	t.Setenv("AOC_SESSION", "")
+	fname := t.TempDir() + "/.env"
+	if _, err := LoadSession(fname); !errors.Is(err, ErrNoSession) {
+		t.Errorf("missing file: got %v, want ErrNoSession", err)
+	}
+
+	writeFile(t, fname, "sessiontoken=abc123\n")
+	if s, err := LoadSession(fname); err != nil || s != "abc123" {
+		t.Errorf("got %q, %v", s, err)
+	}
+
+	t.Setenv("AOC_SESSION", "fromenv")
+	if s, _ := LoadSession(fname); s != "fromenv" {
+		t.Errorf("got %q, want value of AOC_SESSION", s)
+	}
+}
+
+func writeFile(t *testing.T, fname, content string) {
+	t.Helper()
+	if err := os.WriteFile(fname, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
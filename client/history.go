@@ -0,0 +1,93 @@
+/*
+ * History of submitted answers
+ *
+ * All attempts are logged in a JSON file, so that the same wrong answer
+ * is never submitted twice
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ErrAlreadyTried is returned by History.Check for answers that need not be
+// submitted (again)
+var ErrAlreadyTried = errors.New("answer not submitted")
+
+type Attempt struct {
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+type History struct {
+	fname    string
+	Attempts []Attempt
+}
+
+// Load the history from the given file - a missing file is an empty history
+func LoadHistory(fname string) (*History, error) {
+	h := &History{fname: fname}
+	data, err := os.ReadFile(fname)
+	if os.IsNotExist(err) {
+		return h, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &h.Attempts); err != nil {
+		return nil, fmt.Errorf("reading %v: %w", fname, err)
+	}
+	return h, nil
+}
+
+// Check whether an answer is worth submitting. It is not, if the part is
+// solved already, the same answer was wrong before, or a numeric answer
+// is out of the bounds known from earlier "too high"/"too low" verdicts
+func (h *History) Check(day, part int, answer string) error {
+	num, numErr := strconv.Atoi(answer)
+	for _, a := range h.Attempts {
+		if a.Day != day || a.Part != part {
+			continue
+		}
+		if a.Verdict == Correct {
+			return fmt.Errorf("%w: part %v of day %02d is solved already (answer %v)", ErrAlreadyTried, part, day, a.Answer)
+		}
+		if a.Answer == answer && a.Verdict.IsWrong() {
+			return fmt.Errorf("%w: %v was %v at %v", ErrAlreadyTried, answer, a.Verdict, a.Time.Format(time.DateTime))
+		}
+		prev, err := strconv.Atoi(a.Answer)
+		if numErr != nil || err != nil {
+			continue
+		}
+		if a.Verdict == TooHigh && num >= prev {
+			return fmt.Errorf("%w: %v is too high, %v was too high already", ErrAlreadyTried, answer, a.Answer)
+		}
+		if a.Verdict == TooLow && num <= prev {
+			return fmt.Errorf("%w: %v is too low, %v was too low already", ErrAlreadyTried, answer, a.Answer)
+		}
+	}
+	return nil
+}
+
+// Add an attempt and save the history
+func (h *History) Add(a Attempt) error {
+	h.Attempts = append(h.Attempts, a)
+	data, err := json.MarshalIndent(h.Attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.fname), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.fname, data, 0o644)
+}
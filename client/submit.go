@@ -0,0 +1,137 @@
+/*
+ * Submitting answers
+ *
+ * The answer is posted to the website, the verdict is parsed from the
+ * returned page (there is no API, so this is just text matching)
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package client
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict int
+
+const (
+	Unknown Verdict = iota
+	Correct
+	Wrong
+	TooHigh
+	TooLow
+	Wait          // answered too recently
+	AlreadySolved // the part is solved already (or locked)
+)
+
+var verdictNames = []string{"unknown", "correct", "wrong", "too high", "too low", "wait", "already solved"}
+
+func (v Verdict) String() string {
+	if int(v) < len(verdictNames) {
+		return verdictNames[v]
+	}
+	return fmt.Sprintf("Verdict(%d)", int(v))
+}
+
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Verdict) UnmarshalText(b []byte) error {
+	for i, n := range verdictNames {
+		if n == string(b) {
+			*v = Verdict(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", b)
+}
+
+// is the answer known to be wrong?
+func (v Verdict) IsWrong() bool {
+	return v == Wrong || v == TooHigh || v == TooLow
+}
+
+// Result of a submission
+type Result struct {
+	Verdict Verdict
+	Wait    time.Duration // time left to wait, if known
+	Message string        // text of the answer page
+}
+
+// Submit the answer for a part of the given day
+func (c *Client) Submit(day, part int, answer string) (Result, error) {
+	if err := c.checkUnlocked(day); err != nil {
+		return Result{}, err
+	}
+	form := url.Values{}
+	form.Set("level", fmt.Sprint(part))
+	form.Set("answer", answer)
+	req, err := c.newRequest(http.MethodPost, fmt.Sprintf("/%v/day/%v/answer", Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("submitting answer for day %02d: %v", day, resp.Status)
+	}
+	return ParseResult(string(body)), nil
+}
+
+var (
+	reArticle = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	reTag     = regexp.MustCompile(`<[^>]*>`)
+	reSpace   = regexp.MustCompile(`\s+`)
+	reWait    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// Parse the verdict from the page returned for a submission
+func ParseResult(page string) Result {
+	text := page
+	if m := reArticle.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = html.UnescapeString(reTag.ReplaceAllString(text, ""))
+	text = strings.TrimSpace(reSpace.ReplaceAllString(text, " "))
+
+	res := Result{Message: text}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		res.Verdict = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		res.Verdict = Wrong
+		if strings.Contains(text, "your answer is too high") {
+			res.Verdict = TooHigh
+		} else if strings.Contains(text, "your answer is too low") {
+			res.Verdict = TooLow
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		res.Verdict = Wait
+		if m := reWait.FindStringSubmatch(text); m != nil {
+			min, _ := strconv.Atoi(m[1]) // may be empty
+			sec, _ := strconv.Atoi(m[2])
+			res.Wait = time.Duration(min)*time.Minute + time.Duration(sec)*time.Second
+		}
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		res.Verdict = AlreadySolved
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// answer pages as returned by the website
+var pages = map[string]string{
+	"correct":  `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to restoring snow operations. <a href="/2023/day/3#part2">[Continue to Part Two]</a></p></article></main>`,
+	"wrong":    `<main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2023/about">about page</a>, or you can ask for hints on the <a href="https://www.reddit.com/r/adventofcode/" target="_blank">subreddit</a>.  Please wait one minute before trying again. <a href="/2023/day/3">[Return to Day 3]</a></p></article></main>`,
+	"too high": `<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. <a href="/2023/day/3">[Return to Day 3]</a></p></article></main>`,
+	"too low":  `<main><article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again. <a href="/2023/day/3">[Return to Day 3]</a></p></article></main>`,
+	"wait":     `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 23s left to wait. <a href="/2023/day/3">[Return to Day 3]</a></p></article></main>`,
+	"solved":   `<main><article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2023/day/3">[Return to Day 3]</a></p></article></main>`,
+}
+
+func TestParseResult(t *testing.T) {
+	tests := []struct {
+		page string
+		want Verdict
+		wait time.Duration
+	}{
+		{"correct", Correct, 0},
+		{"wrong", Wrong, 0},
+		{"too high", TooHigh, 0},
+		{"too low", TooLow, 0},
+		{"wait", Wait, 83 * time.Second},
+		{"solved", AlreadySolved, 0},
+	}
+	for _, tc := range tests {
+		res := ParseResult(pages[tc.page])
+		if res.Verdict != tc.want || res.Wait != tc.wait {
+			t.Errorf("%v: got %v (%v), want %v (%v)", tc.page, res.Verdict, res.Wait, tc.want, tc.wait)
+		}
+	}
+	if res := ParseResult("<html>Something else</html>"); res.Verdict != Unknown {
+		t.Errorf("got %v, want unknown", res.Verdict)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /2023/day/3/answer", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("level") != "1" {
+			w.Write([]byte(pages["solved"]))
+		} else if r.FormValue("answer") == "4361" {
+			w.Write([]byte(pages["correct"]))
+		} else {
+			w.Write([]byte(pages["too high"]))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("secret")
+	c.BaseURL = srv.URL
+	c.MinInterval = 0
+
+	tests := []struct {
+		part   int
+		answer string
+		want   Verdict
+	}{
+		{1, "5000", TooHigh},
+		{1, "4361", Correct},
+		{2, "1", AlreadySolved},
+	}
+	for _, tc := range tests {
+		res, err := c.Submit(3, tc.part, tc.answer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.Verdict != tc.want {
+			t.Errorf("part %v, answer %v: got %v, want %v", tc.part, tc.answer, res.Verdict, tc.want)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "submissions.json")
+	h, err := LoadHistory(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Add(Attempt{Day: 3, Part: 1, Answer: "5000", Verdict: TooHigh})
+	h.Add(Attempt{Day: 3, Part: 1, Answer: "100", Verdict: TooLow})
+	h.Add(Attempt{Day: 3, Part: 1, Answer: "abc", Verdict: Wrong})
+	h.Add(Attempt{Day: 3, Part: 2, Answer: "42", Verdict: Correct})
+
+	// reload from disk
+	h, err = LoadHistory(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Attempts) != 4 || h.Attempts[0].Verdict != TooHigh {
+		t.Fatalf("history not saved properly: %v", h.Attempts)
+	}
+
+	tests := []struct {
+		part   int
+		answer string
+		ok     bool
+	}{
+		{1, "5000", false},
+		{1, "6000", false},
+		{1, "100", false},
+		{1, "99", false},
+		{1, "abc", false},
+		{1, "4361", true},
+		{1, "def", true},
+		{2, "43", false},
+	}
+	for _, tc := range tests {
+		err := h.Check(3, tc.part, tc.answer)
+		if tc.ok && err != nil {
+			t.Errorf("part %v, answer %v: unexpected error %v", tc.part, tc.answer, err)
+		} else if !tc.ok && !errors.Is(err, ErrAlreadyTried) {
+			t.Errorf("part %v, answer %v: got %v, want ErrAlreadyTried", tc.part, tc.answer, err)
+		}
+	}
+	if err := h.Check(4, 1, "5000"); err != nil {
+		t.Errorf("other day: unexpected error %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+/*
+ * All days known to the runner - a day needs to be imported here
+ * to register itself
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package main
+
+import (
+	_ "aoc23/d01"
+	_ "aoc23/d02"
+	_ "aoc23/d03"
+	_ "aoc23/d04"
+	_ "aoc23/d05"
+	_ "aoc23/d06"
+	_ "aoc23/d07"
+	_ "aoc23/d08"
+	_ "aoc23/d09"
+	_ "aoc23/d10"
+	_ "aoc23/d11"
+	_ "aoc23/d12"
+	_ "aoc23/d13"
+	_ "aoc23/d14"
+	_ "aoc23/d15"
+	_ "aoc23/d16"
+	_ "aoc23/d19"
+	_ "aoc23/d20"
+)
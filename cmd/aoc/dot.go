@@ -0,0 +1,79 @@
+/*
+ * AoC 2023 runner - DOT export
+ *
+ * Write the structure of the input of a day in Graphviz DOT format, e.g.
+ *
+ *	aoc dot 20 -input real | dot -Tsvg > d20.svg
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package main
+
+import (
+	"aoc23/puzzle"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// write the input structure of a day, e.g. "aoc dot 08 -input real -o d08.dot"
+func dotCmd(args []string) error {
+	fs := flag.NewFlagSet("dot", flag.ExitOnError)
+	part := fs.Int("part", 1, "example input of part 1 or 2 (for -input test)")
+	input := fs.String("input", "test", "input to use: test (example from puzzle) or real")
+	inputfile := fs.String("f", "", "name of input file (default dNN/input.txt, else from cache)")
+	output := fs.String("o", "", "name of output file (default stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aoc dot <day> [flags]")
+		fs.PrintDefaults()
+	}
+	num, err := parseDayArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *input != "test" && *input != "real" {
+		return fmt.Errorf("invalid input %q (must be test or real)", *input)
+	}
+	log.SetOutput(io.Discard)
+
+	d, ok := puzzle.Get(num)
+	if !ok {
+		return fmt.Errorf("day %02d is not available", num)
+	}
+	s := d.New()
+	v, ok := s.(puzzle.Visualizer)
+	if !ok {
+		return fmt.Errorf("day %02d has no DOT export", num)
+	}
+
+	var r io.Reader = strings.NewReader(d.Example(*part))
+	if *input == "real" {
+		fname, err := inputFile(num, *inputfile)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(fname)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	if err := s.Parse(r); err != nil {
+		return fmt.Errorf("parsing input: %w", err)
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return v.WriteDOT(w)
+}
@@ -0,0 +1,197 @@
+/*
+ * AoC 2023 runner
+ *
+ * Single binary dispatching to every registered day, e.g.
+ *
+ *	aoc run 14 -part 2 -input real
+ *	aoc run all
+ *	aoc list
+ *	aoc init 21
+ *	aoc fetch 21
+ *	aoc submit 21 1 12345
+ *	aoc dot 20 -input real
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package main
+
+import (
+	"aoc23/cache"
+	"aoc23/puzzle"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const usage = `Usage: aoc <command> [arguments]
+
+Commands:
+  run <day|all> [flags]   run the given day (or all days)
+  list                    list all registered days
+  init <day> [flags]      create directory and main.go of a day, download the input
+  fetch <day> [flags]     download the input of a day
+  submit <day> <part> <answer> [flags]
+                          submit an answer
+  dot <day> [flags]       write the structure of the input in Graphviz DOT format
+
+Run "aoc <command> -h" for the flags of a command
+`
+
+func main() {
+	log.SetPrefix("  ")
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCmd(os.Args[2:])
+	case "init":
+		err = initCmd(os.Args[2:])
+	case "fetch":
+		err = fetchCmd(os.Args[2:])
+	case "submit":
+		err = submitCmd(os.Args[2:])
+	case "dot":
+		err = dotCmd(os.Args[2:])
+	case "list":
+		for _, n := range puzzle.Numbers() {
+			fmt.Printf("Day %02d\n", n)
+		}
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+	default:
+		err = fmt.Errorf("unknown command %q", os.Args[1])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run one or all days, e.g. "aoc run 14 -part 2 -input real"
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	part := fs.Int("part", 0, "run only part 1 or 2 (0 = both)")
+	input := fs.String("input", "test", "input to use: test (example from puzzle) or real")
+	inputfile := fs.String("f", "", "name of input file (default dNN/input.txt, else from cache)")
+	verbose := fs.Bool("v", false, "verbose, show log output of the days")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aoc run <day|all> [flags]")
+		fs.PrintDefaults()
+	}
+
+	// allow flags before and after the day
+	var day string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		day = args[0]
+		args = args[1:]
+	}
+	fs.Parse(args)
+	if day == "" {
+		day = fs.Arg(0)
+	}
+
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part %v", *part)
+	}
+	if *input != "test" && *input != "real" {
+		return fmt.Errorf("invalid input %q (must be test or real)", *input)
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	var days []int
+	if day == "all" {
+		days = puzzle.Numbers()
+	} else {
+		num, err := strconv.Atoi(day)
+		if err != nil {
+			return fmt.Errorf("invalid day %q", day)
+		}
+		days = []int{num}
+	}
+
+	for _, num := range days {
+		d, ok := puzzle.Get(num)
+		if !ok {
+			return fmt.Errorf("day %02d is not available", num)
+		}
+		var fname string
+		if *input == "real" {
+			var err error
+			if fname, err = inputFile(num, *inputfile); err != nil {
+				return fmt.Errorf("day %02d: %w", num, err)
+			}
+		}
+
+		fmt.Printf("Day %02d\n", num)
+		for p := 1; p <= 2; p++ {
+			if *part != 0 && *part != p {
+				continue
+			}
+			if *input == "real" {
+				f, err := os.Open(fname)
+				if err != nil {
+					return err
+				}
+				runPart(d, p, f)
+				f.Close()
+			} else {
+				runPart(d, p, strings.NewReader(d.Example(p)))
+			}
+		}
+	}
+	return nil
+}
+
+// name of the input file of a day - if not given, it is dNN/input.txt or
+// (if that does not exist) the input from the local cache
+func inputFile(num int, fname string) (string, error) {
+	if fname != "" {
+		_, err := os.Stat(fname)
+		return fname, err
+	}
+	fname = filepath.Join(puzzle.Dir(num), "input.txt")
+	_, err := os.Stat(fname)
+	if os.IsNotExist(err) {
+		if cached, cerr := cache.Path(num); cerr == nil {
+			if _, cerr := os.Stat(cached); cerr == nil {
+				return cached, nil
+			}
+		}
+		return fname, fmt.Errorf("%w (and not cached - try aoc fetch %v)", err, num)
+	}
+	return fname, err
+}
+
+// run a single part and print its result
+func runPart(d puzzle.Day, part int, r io.Reader) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			fmt.Printf("Result part %02d: failed: %v\n\n", part, rec)
+		}
+	}()
+	startTime := time.Now()
+	result, err := d.Solve(part, r)
+	elapsed := time.Since(startTime)
+	if errors.Is(err, puzzle.ErrNotImplemented) {
+		fmt.Printf("Part %02d not implemented yet\n\n", part)
+	} else if err != nil {
+		fmt.Printf("Result part %02d: failed: %v\n\n", part, err)
+	} else {
+		fmt.Printf("Result part %02d (%v): %v\n\n", part, elapsed, result)
+	}
+}
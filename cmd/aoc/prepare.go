@@ -0,0 +1,199 @@
+/*
+ * Commands to prepare a day: "init" creates the directory of a day from
+ * the template and downloads the input, "fetch" only downloads the input
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package main
+
+import (
+	"aoc23/client"
+	"aoc23/puzzle"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	templateFile = "main-tmpl.go"
+	daysFile     = "cmd/aoc/days.go"
+	envFile      = ".env"
+)
+
+// flags shared by all commands talking to the website
+type webFlags struct {
+	baseURL  *string
+	interval *time.Duration
+	refresh  *bool // init and fetch only
+}
+
+func addWebFlags(fs *flag.FlagSet) webFlags {
+	return webFlags{
+		baseURL:  fs.String("url", client.DefaultBaseURL, "base URL of the AoC website"),
+		interval: fs.Duration("interval", client.DefaultMinInterval, "minimum interval between two requests"),
+	}
+}
+
+func addFetchFlags(fs *flag.FlagSet) webFlags {
+	wf := addWebFlags(fs)
+	wf.refresh = fs.Bool("refresh", false, "ask the server even if the input is cached")
+	return wf
+}
+
+func (wf webFlags) client() (*client.Client, error) {
+	// a missing session is no error yet, the input may be cached
+	session, err := client.LoadSession(envFile)
+	if err != nil && !errors.Is(err, client.ErrNoSession) {
+		return nil, err
+	}
+	c := client.New(session)
+	c.BaseURL = *wf.baseURL
+	c.MinInterval = *wf.interval
+	if err := c.UseCacheStamp(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parse flags and the day number, allowing flags before and after the day
+func parseDayArgs(fs *flag.FlagSet, args []string) (int, error) {
+	var day string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		day = args[0]
+		args = args[1:]
+	}
+	fs.Parse(args)
+	if day == "" {
+		day = fs.Arg(0)
+	}
+	num, err := strconv.Atoi(day)
+	if err != nil || num < 1 || num > 25 {
+		return 0, fmt.Errorf("invalid day %q", day)
+	}
+	return num, nil
+}
+
+// create directory and main.go of a day, register it and download the input
+func initCmd(args []string) error {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	wf := addFetchFlags(fs)
+	noFetch := fs.Bool("nofetch", false, "do not download the input")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aoc init <day> [flags]")
+		fs.PrintDefaults()
+	}
+	day, err := parseDayArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Preparing day %02d\n", day)
+	dir := puzzle.Dir(day)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("directory (or file) %v exists", dir)
+	}
+
+	fmt.Println("Copying source file...")
+	tmpl, err := os.ReadFile(templateFile)
+	if err != nil {
+		return err
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+	src := renderTemplate(string(tmpl), day)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("Registering day with the runner...")
+	days, err := os.ReadFile(daysFile)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(daysFile, []byte(addDayImport(string(days), day)), 0o644); err != nil {
+		return err
+	}
+
+	if !*noFetch {
+		if err := fetch(wf, day); err != nil {
+			fmt.Printf("Error downloading input file - please check manually: %v\n", err)
+		}
+	}
+	fmt.Println("Good to go")
+	return nil
+}
+
+// copy the input of a day into its directory - it is downloaded only if not
+// in the local cache yet. Any existing input file will be overwritten
+func fetchCmd(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	wf := addFetchFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aoc fetch <day> [flags]")
+		fs.PrintDefaults()
+	}
+	day, err := parseDayArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(puzzle.Dir(day), 0o755); err != nil {
+		return err
+	}
+	return fetch(wf, day)
+}
+
+func fetch(wf webFlags, day int) error {
+	c, err := wf.client()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Getting input file...")
+	data, err := c.CachedInput(day, *wf.refresh)
+	if errors.Is(err, client.ErrNoSession) {
+		return fmt.Errorf("%w - set AOC_SESSION or put sessiontoken=... into %v", err, envFile)
+	} else if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(puzzle.Dir(day), "input.txt"), data, 0o644)
+}
+
+// replace the placeholders of the template (see main-tmpl.go)
+func renderTemplate(tmpl string, day int) string {
+	r := strings.NewReplacer(
+		"//go:build ignore\n\n", "",
+		"package dDD", "package "+puzzle.Dir(day),
+		"Number:    DD", fmt.Sprintf("Number:    %v", day),
+		"Day DD", fmt.Sprintf("Day %02d", day),
+	)
+	return r.Replace(tmpl)
+}
+
+// add the import of a day to the source of days.go (keeping it sorted)
+func addDayImport(src string, day int) string {
+	imp := fmt.Sprintf("\t_ \"aoc23/%v\"\n", puzzle.Dir(day))
+	if strings.Contains(src, imp) {
+		return src
+	}
+	start := strings.Index(src, "import (\n")
+	end := strings.Index(src, "\n)\n")
+	if start < 0 || end < start {
+		return src
+	}
+	start += len("import (\n")
+	lines := strings.SplitAfter(src[start:end+1], "\n")
+	pos := len(lines) - 1
+	for i, l := range lines {
+		if l > imp {
+			pos = i
+			break
+		}
+	}
+	lines = append(lines[:pos], append([]string{imp}, lines[pos:]...)...)
+	return src[:start] + strings.Join(lines, "") + src[end+1:]
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := "//go:build ignore\n\n/*\n * Day DD of AoC 2023\n */\npackage dDD\n\n\t\tNumber:    DD,\n"
+	want := "/*\n * Day 07 of AoC 2023\n */\npackage d07\n\n\t\tNumber:    7,\n"
+	if got := renderTemplate(tmpl, 7); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestAddDayImport(t *testing.T) {
+	src := "package main\n\nimport (\n\t_ \"aoc23/d01\"\n\t_ \"aoc23/d20\"\n)\n"
+	tests := []struct {
+		day  int
+		want []string
+	}{
+		{17, []string{"d01", "d17", "d20"}},
+		{21, []string{"d01", "d20", "d21"}},
+		{20, []string{"d01", "d20"}},
+	}
+	for _, tc := range tests {
+		got := addDayImport(src, tc.day)
+		want := "package main\n\nimport (\n"
+		for _, d := range tc.want {
+			want += "\t_ \"aoc23/" + d + "\"\n"
+		}
+		want += ")\n"
+		if got != want {
+			t.Errorf("day %v: got\n%v\nwant\n%v", tc.day, got, want)
+		}
+		if !strings.HasSuffix(got, ")\n") {
+			t.Errorf("day %v: import block broken", tc.day)
+		}
+	}
+}
+
+// an empty day must be reported as invalid, not panic
+func TestEmptyDay(t *testing.T) {
+	for name, cmd := range map[string]func([]string) error{"run": runCmd, "init": initCmd, "fetch": fetchCmd, "dot": dotCmd} {
+		if err := cmd([]string{""}); err == nil || !strings.Contains(err.Error(), `invalid day ""`) {
+			t.Errorf("%v: got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Command to submit an answer: "aoc submit <day> <part> <answer>"
+ *
+ * All attempts are logged (in the cache directory), the same wrong answer
+ * is never submitted twice
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package main
+
+import (
+	"aoc23/cache"
+	"aoc23/client"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const historyFile = "submissions.json"
+
+func submitCmd(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	wf := addWebFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aoc submit <day> <part> <answer> [flags]")
+		fs.PrintDefaults()
+	}
+	// positional arguments first, flags after (answers may be negative)
+	var pos []string
+	for len(args) > 0 && len(pos) < 3 && !isFlag(args[0]) {
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+	fs.Parse(args)
+	pos = append(pos, fs.Args()...)
+	if len(pos) != 3 {
+		fs.Usage()
+		return errors.New("need day, part and answer")
+	}
+	day, err := strconv.Atoi(pos[0])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", pos[0])
+	}
+	part, err := strconv.Atoi(pos[1])
+	if err != nil || part < 1 || part > 2 {
+		return fmt.Errorf("invalid part %q", pos[1])
+	}
+	answer := pos[2]
+
+	dir, err := cache.Dir()
+	if err != nil {
+		return err
+	}
+	history, err := client.LoadHistory(filepath.Join(dir, historyFile))
+	if err != nil {
+		return err
+	}
+	if err := history.Check(day, part, answer); err != nil {
+		return err
+	}
+
+	c, err := wf.client()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Submitting %v for day %02d, part %v...\n", answer, day, part)
+	res, err := c.Submit(day, part, answer)
+	if errors.Is(err, client.ErrNoSession) {
+		return fmt.Errorf("%w - set AOC_SESSION or put sessiontoken=... into %v", err, envFile)
+	} else if err != nil {
+		return err
+	}
+	err = history.Add(client.Attempt{Day: day, Part: part, Answer: answer, Verdict: res.Verdict, Time: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	switch res.Verdict {
+	case client.Wait:
+		fmt.Printf("Answered too recently - wait %v\n", res.Wait)
+	case client.Unknown:
+		fmt.Printf("Unknown response: %v\n", res.Message)
+	default:
+		fmt.Printf("Answer is %v\n", res.Verdict)
+	}
+	return nil
+}
+
+func isFlag(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-' && (arg[1] < '0' || arg[1] > '9')
+}
@@ -8,46 +8,46 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d01
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
 	"log"
 	"regexp"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:     1,
+		TestInput:  testinput,
+		TestInput2: testinput2,
+		New:        func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
-	part01()
-	part02()
+type solver struct {
+	puzzle.Input
 }
 
-func part01() {
-	var input []string
-	if TESTMODE {
-		input = tools.ReadInputString(`1abc2
+var testinput = `1abc2
 pqr3stu8vwx
 a1b2c3d4e5f
-treb7uchet`)
-	} else {
-		input = tools.ReadInputFile(*inputfile)
-	}
+treb7uchet`
+
+var testinput2 = `two1nine
+eightwothree
+abcone2threexyz
+xtwone3four
+4nineeightseven2
+zoneight234
+7pqrstsixteen`
 
+func (s *solver) Part1() (any, error) {
 	re := regexp.MustCompile(`[0-9]`)
 	total := 0
 	cnt := 0
-	for _, line := range input {
+	for _, line := range s.Lines {
 		cnt += 1
 		numArr := re.FindAllString(line, -1)
 		val := tools.Str2Int(numArr[0] + numArr[len(numArr)-1])
@@ -55,29 +55,16 @@ treb7uchet`)
 		total += val
 	}
 
-	fmt.Println(total)
+	return total, nil
 }
 
-func part02() {
-	var input []string
-	if TESTMODE {
-		input = tools.ReadInputString(`two1nine
-eightwothree
-abcone2threexyz
-xtwone3four
-4nineeightseven2
-zoneight234
-7pqrstsixteen`)
-	} else {
-		input = tools.ReadInputFile(*inputfile)
-	}
-
+func (s *solver) Part2() (any, error) {
 	restr := "one|two|three|four|five|six|seven|eight|nine"
 	re := regexp.MustCompile(restr + "|[1-9]")
 	rere := regexp.MustCompile(tools.ReverseStr(restr) + "|[1-9]")
 	total := 0
 	cnt := 0
-	for _, line := range input {
+	for _, line := range s.Lines {
 		cnt += 1
 		first := re.FindString(line)
 		last := tools.ReverseStr(rere.FindString(tools.ReverseStr(line)))
@@ -87,7 +74,7 @@ zoneight234
 		total += val
 	}
 
-	fmt.Println(total)
+	return total, nil
 }
 
 func str2num(s string) int {
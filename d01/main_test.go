@@ -0,0 +1,18 @@
+package d01
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 142},
+		{Part: 2, Input: testinput2, Want: 281},
+	}
+	puzzletest.Run(t, 1, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 1)
+}
@@ -7,31 +7,36 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d02
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
+	"io"
 	"log"
 	"regexp"
 	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    2,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	games []Game
+}
 
-	part01()
-	part02()
+func (s *solver) Parse(r io.Reader) error {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return err
+	}
+	s.games, err = buildGamesFromInput(lines)
+	return err
 }
 
 var testinput = `Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green
@@ -41,9 +46,9 @@ Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red
 Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green
 `
 
-func part01() {
+func (s *solver) Part1() (any, error) {
 
-	games := buildGamesFromInput()
+	games := s.games
 
 	maxCubes := map[string]int{
 		"red":   12,
@@ -66,12 +71,12 @@ func part01() {
 			}
 		}
 	}
-	fmt.Printf("Total sum of valid game IDs: %v\n", sumids)
+	return sumids, nil
 }
 
-func part02() {
+func (s *solver) Part2() (any, error) {
 
-	games := buildGamesFromInput()
+	games := s.games
 
 	sumpowers := 0
 	for _, g := range games {
@@ -93,7 +98,7 @@ func part02() {
 		sumpowers += minCubes["red"] * minCubes["green"] * minCubes["blue"]
 
 	}
-	fmt.Printf("Sum of game powers: %v\n", sumpowers)
+	return sumpowers, nil
 }
 
 type Game struct {
@@ -102,43 +107,37 @@ type Game struct {
 	selects    []map[string]int
 }
 
-func buildGamesFromInput() []Game {
+// one line of input, sets are parsed separately
+type gameLine struct {
+	Number int
+	Sets   string
+}
 
-	var input []string
-	if TESTMODE {
-		input = tools.ReadInputString(testinput)
-	} else {
-		input = tools.ReadInputFile(*inputfile)
-	}
+func buildGamesFromInput(input []string) ([]Game, error) {
 
 	games := []Game{}
 
-	cnt := 0
-	reg := regexp.MustCompile(`[0-9]+`)
 	res := regexp.MustCompile(`([0-9]+) (red|green|blue)`)
-	for _, line := range input {
-		cnt += 1
+	for i, line := range input {
 		var game Game
-		elems := strings.Split(line, ":")
-		noGame := reg.FindString(elems[0])
-		selects := strings.Split(elems[1], ";")
+		gl, err := tools.ParseLine[gameLine]("Game {number}: {sets}", line)
+		if err != nil {
+			return nil, &tools.LineError{Line: i + 1, Err: err}
+		}
+		selects := strings.Split(gl.Sets, ";")
 
-		game.number = tools.Str2Int(noGame)
+		game.number = gl.Number
 		game.numSelects = len(selects)
 		game.selects = make([]map[string]int, game.numSelects)
-		// fmt.Printf("%d: Game %v with %v selects\n", cnt, noGame, len(selects))
 
 		for i := 0; i < game.numSelects; i++ {
 			game.selects[i] = make(map[string]int)
 			r := res.FindAllStringSubmatch(selects[i], -1)
-			// fmt.Printf("%d: %q\n", cnt, r)
 			for j := 0; j < len(r); j++ {
-				// fmt.Printf("Setting %v to %v\n", r[j][2], r[j][1])
 				game.selects[i][r[j][2]] = tools.Str2Int(r[j][1])
 			}
-			// fmt.Printf("%d: game selects: %v\n", cnt, game.selects[i])
 		}
 		games = append(games, game)
 	}
-	return games
+	return games, nil
 }
@@ -0,0 +1,18 @@
+package d02
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 8},
+		{Part: 2, Input: testinput, Want: 2286},
+	}
+	puzzletest.Run(t, 2, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 2)
+}
@@ -7,31 +7,26 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d03
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
 	"log"
 	"regexp"
 	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    3,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	part01()
-	part02()
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `467..114..
@@ -45,14 +40,16 @@ var testinput = `467..114..
 ...$.*....
 .664.598..`
 
-func part01() {
-	total := processWith(addLineNumbers)
-	fmt.Printf("Part 01 total: %v\n", total)
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
+	total := processWith(lines, addLineNumbers)
+	return total, nil
 }
 
-func part02() {
-	total := processWith(getGearValues)
-	fmt.Printf("Part 02 total: %v\n", total)
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
+	total := processWith(lines, getGearValues)
+	return total, nil
 }
 
 // a calculator is a function that gets three lines of input, analyzes the middle
@@ -63,14 +60,7 @@ func part02() {
 type calculator func([3]string) int
 
 // general input processor, calling a specific calculator
-func processWith(fn calculator) int {
-	var input []string
-	if TESTMODE {
-		input = tools.ReadInputString(testinput)
-	} else {
-		input = tools.ReadInputFile(*inputfile)
-	}
-
+func processWith(input []string, fn calculator) int {
 	buf := [3]string{}
 	cnt := 0
 	var noop string
@@ -0,0 +1,18 @@
+package d03
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 4361},
+		{Part: 2, Input: testinput, Want: 467835},
+	}
+	puzzletest.Run(t, 3, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 3)
+}
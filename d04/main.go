@@ -7,31 +7,26 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d04
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
 	"log"
 	"slices"
 	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    4,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	part01()
-	part02()
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
@@ -41,10 +36,10 @@ Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83
 Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36
 Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11`
 
-func part01() {
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
 	cnt := 0
 	total := 0
-	lines := getInput()
 	for _, line := range lines {
 		log.Printf("Processing line %v with len %v\n", cnt, len(line))
 		parts := strings.Split(line, ":")
@@ -78,13 +73,13 @@ func part01() {
 		cnt++
 		total += val
 	}
-	fmt.Printf("Result part 01: %v\n", total)
+	return total, nil
 }
 
-func part02() {
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
 	cnt := 0
 	total := 0
-	lines := getInput()
 	stack := make(map[int]int)
 
 	for i := 0; i < len(lines); i++ {
@@ -135,14 +130,5 @@ func part02() {
 	for i := 0; i < len(stack); i++ {
 		total += stack[i]
 	}
-	fmt.Printf("Result part 02: %v\n", total)
-}
-
-func getInput() []string {
-	if TESTMODE {
-		return tools.ReadInputString(testinput)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	return total, nil
 }
@@ -0,0 +1,18 @@
+package d04
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 13},
+		{Part: 2, Input: testinput, Want: 30},
+	}
+	puzzletest.Run(t, 4, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 4)
+}
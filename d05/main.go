@@ -8,32 +8,51 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d05
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
+	"aoc23/tools/interval"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    5,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	seeds []int
+	maps  [][]triple
+}
 
-	// testfilter()
-	// testsplit()
-	part01()
-	part02()
+func (s *solver) Parse(r io.Reader) error {
+	return tools.NewSectionParser().
+		Header(`^seeds:(.*)$`, func(match, _ []string, _ int) error {
+			s.seeds = tools.ReadInts(match[1])
+			return nil
+		}).
+		Header(`^(\w+)-to-(\w+) map:$`, func(match, lines []string, start int) error {
+			currmap := []triple{}
+			for i, line := range lines {
+				vals := tools.ReadInts(line)
+				if len(vals) != 3 {
+					return &tools.LineError{Line: start + i, Err: fmt.Errorf("%v: invalid line %q", match[0], line)}
+				}
+				currmap = append(currmap, triple(vals))
+			}
+			log.Printf("Reading %v %v entries", match[0], len(currmap))
+			s.maps = append(s.maps, currmap)
+			return nil
+		}).
+		ParseReader(r)
 }
 
 var testinput = `seeds: 79 14 55 13
@@ -71,15 +90,9 @@ humidity-to-location map:
 56 93 4`
 
 type triple [3]int
-type tuple [2]int
-
-func part01() {
 
-	lines := getInput()
-	lines = append(lines, "")
-
-	seeds := tools.ReadInts(strings.Split(lines[0], ":")[1])
-	maps := readmap(lines[1:])
+func (s *solver) Part1() (any, error) {
+	seeds, maps := s.seeds, s.maps
 
 	minval := -1
 	for _, s := range seeds {
@@ -99,108 +112,40 @@ func part01() {
 		log.Printf("Seed %v - result %v - minval %v\n", s, val, minval)
 	}
 
-	fmt.Printf("Result part 01: %v\n", minval)
+	return minval, nil
 }
 
-func part02() {
-	lines := getInput()
-	lines = append(lines, "")
-
-	vals := tools.ReadInts(strings.Split(lines[0], ":")[1])
-	seeds := []tuple{}
-	for i := 0; i < len(vals); i += 2 {
-		seeds = append(seeds, tuple{vals[i], vals[i+1]})
+func (s *solver) Part2() (any, error) {
+	vals := s.seeds
+	seeds := interval.RangeSet{}
+	for i := 0; i+1 < len(vals); i += 2 {
+		seeds = seeds.Union(interval.NewSet(interval.FromLength(vals[i], vals[i+1])))
 	}
-	maps := readmap(lines[1:])
 
-	minval := -1
-	for _, s := range seeds {
-		ranges := make([]tuple, 1)
-		ranges[0] = s
-
-		// For each seedmap go through all lines ("filters")
-		// if some range is mapped, keep it aside ("processed"), use
-		// unmapped ranges ("newranges") as input for next filter
-		// process until last line is reached - then reset "processed" and start
-		// with new seedmap
-		cnt := 0
-		for _, seedmap := range maps {
-			// start a new map
-			// log.Printf("Start examining map %v...\n", cnt)
-			processed := make([]tuple, 0)
-
-			// iterate over all lines of map
-			for _, filter := range seedmap {
-
-				newranges := make([]tuple, 0)
-				for _, rng := range ranges {
-					mapped, nomapped := applyfilter(filter, rng)
-					// log.Printf("Filter %v by %v: %v | %v\n", rng, filter, mapped, nomapped)
-					if mapped != nil {
-						processed = append(processed, tuple(mapped))
-					}
-					newranges = append(newranges, nomapped...)
-				}
-				ranges = newranges
-			}
-			// take all processesd as well as remaining non-processed as start point for next seedmap
-			ranges = append(processed, ranges...)
-			cnt++
+	// For each seedmap go through all lines ("filters")
+	// if some range is mapped, keep it aside ("processed"), use
+	// unmapped ranges ("remaining") as input for next filter
+	// process until last line is reached - then take both as
+	// input for the next seedmap
+	for cnt, seedmap := range s.maps {
+		processed := interval.RangeSet{}
+		remaining := seeds
+		for _, filter := range seedmap {
+			// filter is [newstart, oldstart, length]
+			mapped, unmapped := remaining.SplitBy(interval.FromLength(filter[1], filter[2]))
+			processed = processed.Union(mapped.Shift(filter[0] - filter[1]))
+			remaining = unmapped
 		}
-
-		// we end up with a number of ranges and need to identify the minimum start value
-		val := -1
-		for i := 0; i < len(ranges); i++ {
-			v := ranges[i][0]
-			if val == -1 || val > v {
-				val = v
-			}
-		}
-		// if that is less than the overall minimum value, keep it
-		if minval == -1 || minval > val {
-			minval = val
-		}
-		log.Printf("Seed %v - val %v - minval %v\n", s, val, minval)
+		seeds = processed.Union(remaining)
+		log.Printf("Map %v: %v ranges\n", cnt, len(seeds.Ranges()))
 	}
 
-	fmt.Printf("Result part 02: %v\n", minval)
-}
-
-// applies the filter [newstart, oldstart, length] to the range [start, length]
-// E.g.:   applyfilter([0, 25, 5][20, 20]) will create [0, 5] and [[20,5],[30,10]]
-// If there is no match, it returns an empty set
-//
-//	[ [20, 5], [0, 5], [30, 10] ]
-func applyfilter(filter triple, rng tuple) ([]int, []tuple) {
-
-	diff := filter[1] - filter[0]
-	if rng[0]+rng[1] <= filter[1] || rng[0] >= filter[1]+filter[2] {
-		// no match, fully left or fully right
-		return nil, []tuple{rng}
-	} else if rng[0] >= filter[1] && rng[0]+rng[1] <= filter[1]+filter[2] {
-		// full coverage, range is completely within filter and will just be shifted
-		return []int{rng[0] - diff, rng[1]}, nil
-	} else {
-		llen := filter[1] - rng[0]
-		rlen := rng[0] + rng[1] - (filter[1] + filter[2])
-		start := max(rng[0], filter[1])
-		end := min(rng[0]+rng[1], filter[1]+filter[2])
-		len := end - start
-		mapped := []int{start - diff, len}
-		var nomap []tuple = make([]tuple, 0)
-		// if both are > 0, the filter is completly within the range, we have three parts
-		// if only one of them is > 0, we have the range
-		// both < 0 can not happen - that would mean we have full coverage which is covered above
-		if llen > 0 {
-			// range starts before filter
-			nomap = append(nomap, tuple{rng[0], llen})
-		}
-		if rlen > 0 {
-			// range goes beyond filter
-			nomap = append(nomap, [2]int{rng[0] + rng[1] - rlen, rlen})
-		}
-		return mapped, nomap
+	// we end up with a number of ranges and need the minimum start value
+	minval, ok := seeds.Min()
+	if !ok {
+		return nil, errors.New("no seeds")
 	}
+	return minval, nil
 }
 
 func mapval(mapper [3]int, val int) int {
@@ -211,36 +156,3 @@ func mapval(mapper [3]int, val int) int {
 		return val
 	}
 }
-
-func readmap(lines []string) [][]triple {
-	maps := [][]triple{}
-	mode := 0 // 0 waiting, 1 awake
-	logline := ""
-	var currmap []triple
-	for _, line := range lines {
-		if mode == 0 && strings.Contains(line, "map:") {
-			logline = line
-			currmap = []triple{}
-			mode = 1
-		} else if mode == 1 && len(line) != 0 {
-			currmap = append(currmap, triple(tools.ReadInts(line)))
-		} else if mode == 1 && len(line) == 0 {
-			maps = append(maps, currmap)
-			mode = 0
-			log.Printf("Reading %v %v entries", logline, len(currmap))
-		}
-	}
-	return maps
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-}
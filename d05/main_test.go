@@ -0,0 +1,18 @@
+package d05
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 35},
+		{Part: 2, Input: testinput, Want: 46},
+	}
+	puzzletest.Run(t, 5, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 5)
+}
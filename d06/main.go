@@ -13,39 +13,34 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d06
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
 	"log"
 	"math"
 	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    6,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	part01()
-	part02()
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `Time:      7  15   30
 Distance:  9  40  200`
 
-func part01() {
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
 	total := 1
-	lines := getInput()
 	d := tools.ReadInts(strings.Split(lines[0], ":")[1])
 	m := tools.ReadInts(strings.Split(lines[1], ":")[1])
 
@@ -62,12 +57,12 @@ func part01() {
 		total *= int(sec - first + 1)
 	}
 
-	fmt.Printf("Result part 01: %v\n", total)
+	return total, nil
 }
 
-func part02() {
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
 	total := 1
-	lines := getInput()
 	d := readSeparatedInt(strings.Split(lines[0], ":")[1])
 	m := readSeparatedInt(strings.Split(lines[1], ":")[1])
 
@@ -82,19 +77,10 @@ func part02() {
 	// log.Printf(("%v, %v\n"), first, sec)
 	total *= int(sec - first + 1)
 
-	fmt.Printf("Result part 02: %v\n", total)
+	return total, nil
 }
 
 func readSeparatedInt(s string) int {
 	s = strings.Replace(s, " ", "", -1)
-	return tools.Str2Int(s)
-}
-
-func getInput() []string {
-	if TESTMODE {
-		return tools.ReadInputString(testinput)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	return tools.MustAtoi(s)
 }
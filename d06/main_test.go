@@ -0,0 +1,18 @@
+package d06
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 288},
+		{Part: 2, Input: testinput, Want: 71503},
+	}
+	puzzletest.Run(t, 6, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 6)
+}
@@ -10,31 +10,41 @@
  *   - len 2 w/2+3 (3), w/2+2 (5)
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d07
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
+	"io"
 	"log"
 	"sort"
-	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    7,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	plays []play
+}
+
+// one line of input
+type play struct {
+	Cards string
+	Bet   int
+}
 
-	part01()
-	part02()
+func (s *solver) Parse(r io.Reader) error {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return err
+	}
+	s.plays, err = tools.ParseLines[play]("{cards} {bet}", lines)
+	return err
 }
 
 var testinput = `32T3K 765
@@ -165,7 +175,7 @@ func rank2(hand *Hand) int {
 			hand.rank = 7 - len(hand.values)
 		}
 	case 1:
-		fmt.Println("ERR Should not happen as these entries were eliminated")
+		log.Println("ERR Should not happen as these entries were eliminated")
 	case 0: // only single values, all eliminated
 		switch cntJ {
 		case 4, 5:
@@ -220,20 +230,17 @@ func getCardranks(part int) map[byte]int {
 	}
 }
 
-func part01() {
+func (s *solver) Part1() (any, error) {
 	// cnt := 0
 	total := 0
-	lines := getInput()
 	allhands := []Hand{}
 
-	for _, line := range lines {
-		// log.Printf("Processing line %v with len %v\n", cnt, len(line))
+	for _, p := range s.plays {
 		hand := Hand{}
-		parts := strings.Split(line, " ")
-		hand.bet = tools.Str2Int(parts[1])
+		hand.bet = p.Bet
 		hand.values = map[string]int{}
-		hand.raw = parts[0]
-		for _, s := range parts[0] {
+		hand.raw = p.Cards
+		for _, s := range p.Cards {
 			hand.values[string(s)]++
 		}
 		for k := range hand.values {
@@ -253,22 +260,19 @@ func part01() {
 		// fmt.Printf("%v: Hand: %v - rank: %v - bet: %v\n", i, h.raw, rank(&h), h.bet)
 		total += (i + 1) * h.bet
 	}
-	fmt.Printf("Result part 01: %v\n", total)
+	return total, nil
 }
 
-func part02() {
+func (s *solver) Part2() (any, error) {
 	total := 0
-	lines := getInput()
 	allhands := []Hand{}
 
-	for _, line := range lines {
-		// log.Printf("Processing line %v with len %v\n", cnt, len(line))
+	for _, p := range s.plays {
 		hand := Hand{}
-		parts := strings.Split(line, " ")
-		hand.bet = tools.Str2Int(parts[1])
+		hand.bet = p.Bet
 		hand.values = map[string]int{}
-		hand.raw = parts[0]
-		for _, s := range parts[0] {
+		hand.raw = p.Cards
+		for _, s := range p.Cards {
 			hand.values[string(s)]++
 		}
 		for k := range hand.values {
@@ -285,17 +289,8 @@ func part02() {
 
 	for i := range allhands {
 		h := allhands[i]
-		fmt.Printf("%v: Hand: %v - rank: %v - bet: %v\n", i, h.raw, rank2(&h), h.bet)
+		log.Printf("%v: Hand: %v - rank: %v - bet: %v\n", i, h.raw, rank2(&h), h.bet)
 		total += (i + 1) * h.bet
 	}
-	fmt.Printf("Result part 02: %v\n", total)
-}
-
-func getInput() []string {
-	if TESTMODE {
-		return tools.ReadInputString(testinput)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	return total, nil
 }
@@ -0,0 +1,18 @@
+package d07
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 6440},
+		{Part: 2, Input: testinput, Want: 5905},
+	}
+	puzzletest.Run(t, 7, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 7)
+}
@@ -6,30 +6,42 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d08
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
+	"aoc23/tools/dot"
+	"errors"
+	"io"
 	"log"
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:     8,
+		TestInput:  testinput,
+		TestInput2: testinput2,
+		New:        func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	desert DesertMap
+	orders string
+}
 
-	part01()
-	part02()
+func (s *solver) Parse(r io.Reader) error {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return err
+	}
+	s.desert, s.orders, err = buildDesert(lines)
+	return err
 }
 
 var testinput = `LLR
@@ -51,6 +63,34 @@ var testinput2 = `LR
 
 type DesertMap map[string][2]string
 
+// Graph of the map - starts (..A) are green, ends (..Z) red, edges are
+// labelled with the instruction leading to the next node
+func (desert DesertMap) Graph() *dot.Graph {
+	g := &dot.Graph{Name: "desert"}
+	for _, node := range slices.Sorted(maps.Keys(desert)) {
+		n := dot.Node{ID: node}
+		if strings.HasSuffix(node, "A") {
+			n.Color = "green"
+		} else if strings.HasSuffix(node, "Z") {
+			n.Color = "red"
+			n.Shape = "doublecircle"
+		}
+		g.AddNode(n)
+		next := desert[node]
+		if next[0] == next[1] {
+			g.AddEdge(node, next[0], "L,R")
+		} else {
+			g.AddEdge(node, next[0], "L")
+			g.AddEdge(node, next[1], "R")
+		}
+	}
+	return g
+}
+
+func (s *solver) WriteDOT(w io.Writer) error {
+	return s.desert.Graph().Write(w)
+}
+
 func search(start string, match string, desert DesertMap, orders string) int {
 	i := 0
 	pos := start
@@ -67,41 +107,42 @@ func search(start string, match string, desert DesertMap, orders string) int {
 			break
 		}
 	}
-	fmt.Printf("Result for start %v and match %v: %v\n", start, match, i)
+	log.Printf("Result for start %v and match %v: %v\n", start, match, i)
 	return i
 }
 
-func buildDesert(part int) (DesertMap, string) {
-	var lines []string
-	if part == 1 {
-		lines = getInput()
-	} else {
-		lines = getInput(testinput2)
-	}
-	cnt := 0
+type node struct {
+	From, Left, Right string
+}
+
+func buildDesert(lines []string) (DesertMap, string, error) {
 	orders := ""
-	re := regexp.MustCompile(`([0-9A-Z]{3}) = \(([0-9A-Z]{3}), ([0-9A-Z]{3})\)`)
 	var desert DesertMap = make(DesertMap)
-	for _, line := range lines {
-		if cnt == 0 {
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if i == 0 {
 			orders = line
 		} else if len(line) > 0 {
-			parts := re.FindAllStringSubmatch(line, -1)[0]
-			desert[parts[1]] = [2]string{parts[2], parts[3]}
+			n, err := tools.ParseLine[node]("{from} = ({left}, {right})", line)
+			if err != nil {
+				return nil, "", &tools.LineError{Line: i + 1, Err: err}
+			}
+			desert[n.From] = [2]string{n.Left, n.Right}
 		}
-		cnt++
 	}
-	return desert, orders
+	return desert, orders, nil
 }
 
-func part01() {
-	desert, orders := buildDesert(1)
-	total := search("AAA", `.*ZZZ`, desert, orders)
-	fmt.Printf("Result part 01: %v\n", total)
+func (s *solver) Part1() (any, error) {
+	if _, ok := s.desert["AAA"]; !ok {
+		return nil, errors.New("no start position AAA in map")
+	}
+	total := search("AAA", `.*ZZZ`, s.desert, s.orders)
+	return total, nil
 }
 
-func part02() {
-	desert, orders := buildDesert(2)
+func (s *solver) Part2() (any, error) {
+	desert, orders := s.desert, s.orders
 
 	positions := []string{}
 	re := regexp.MustCompile(`.*A`)
@@ -111,22 +152,13 @@ func part02() {
 		}
 	}
 
+	if len(positions) == 0 {
+		return nil, errors.New("no start positions ..A in map")
+	}
+
 	vals := make([]int, len(positions))
 	for i, p := range positions {
 		vals[i] = search(p, `.*Z`, desert, orders)
 	}
-	total := tools.LCM(vals[0], vals[1], vals[2:]...)
-	fmt.Printf("Result part 02: %v\n", total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
+	return tools.LCM(1, vals[0], vals[1:]...)
 }
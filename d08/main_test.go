@@ -0,0 +1,18 @@
+package d08
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 6},
+		{Part: 2, Input: testinput2, Want: 6},
+	}
+	puzzletest.Run(t, 8, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 8)
+}
@@ -8,29 +8,24 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d09
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
 	"log"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    9,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	part01()
-	part02()
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `0 3 6 9 12 15
@@ -88,10 +83,10 @@ func backwardValues(values []int) int {
 	}
 }
 
-func part01() {
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
 	cnt := 0
 	total := 0
-	lines := getInput()
 
 	for _, line := range lines {
 
@@ -101,13 +96,13 @@ func part01() {
 		total += result
 		cnt++
 	}
-	fmt.Printf("Result part 01: %v\n", total)
+	return total, nil
 }
 
-func part02() {
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
 	cnt := 0
 	total := 0
-	lines := getInput()
 
 	for _, line := range lines {
 
@@ -117,18 +112,5 @@ func part02() {
 		total += result
 		cnt++
 	}
-	fmt.Printf("Result part 02: %v\n", total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	return total, nil
 }
@@ -0,0 +1,18 @@
+package d09
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 114},
+		{Part: 2, Input: testinput, Want: 2},
+	}
+	puzzletest.Run(t, 9, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 9)
+}
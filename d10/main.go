@@ -1,38 +1,50 @@
 /*
  * Day 10 of AoC 2023
  *
- * Idea: Build a matrix/maze. Identify start field, its type and one of the two
- * next fields. Then follow the loop, keep track of visitied fields and measure
- * distance. Last find inner fields by counting the edges.
+ * Idea: Build a matrix/maze. Identify start field and its type. Then find the
+ * loop via BFS from the start, which also gives the distances. Last find inner
+ * fields by counting the edges.
  *
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d10
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
+	"aoc23/tools/graph"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"slices"
 	"strings"
-	"time"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:     10,
+		TestInput:  testinput,
+		TestInput2: getNumTest(1),
+		New:        func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	maze tools.Matrix
+}
 
-	part01()
-	part02()
+func (s *solver) Parse(r io.Reader) error {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return err
+	}
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	s.maze, err = tools.ParseMatrix(lines)
+	return err
 }
 
 var testinput = `7-F7-
@@ -89,128 +101,40 @@ func getNumTest(num int) string {
 	}
 }
 
-func part01() {
-	startTime := time.Now()
-
-	lines := getInput()
-	var maze tools.Matrix
-	for _, line := range lines {
-		maze.AddLine(strings.TrimSpace(line))
-	}
-	// fmt.Printf("%v\n", maze)
-
-	start, ok := maze.FindField('S')
-	if !ok {
-		fmt.Println("Can not find startpos!")
-		return
+func (s *solver) Part1() (any, error) {
+	maze := s.maze
+	loop, err := findLoop(&maze)
+	if err != nil {
+		return nil, err
 	}
-	fmt.Printf("start pos: %v\n", start)
 
-	distance := -1
+	// the farthest point of the loop is the one with the largest BFS distance
 	total := 0
-	for i := 0; i < 4; i++ {
-		var pos tools.Position
-		var ok bool
-		if i == 0 {
-			pos, ok = maze.LeftOf(start)
-		} else if i == 1 {
-			pos, ok = maze.RightOf(start)
-		} else if i == 2 {
-			pos, ok = maze.AboveOf(start)
-		} else if i == 3 {
-			pos, ok = maze.BelowOf(start)
-		}
-		distance = 1
-
-		fmt.Printf("new try (%v): %v, ok: %v\n", i, pos, ok)
-		if !ok {
-			continue
-		}
-
-		var last = start
-		for {
-			newpos, ok := move(maze, last, pos)
-			// fmt.Printf("  newpos: %v, ok: %v\n", newpos, ok)
-
-			if ok {
-				last = pos
-				pos = newpos
-				distance++
-				if pos == start {
-					distance /= 2
-					break
-				}
-			} else {
-				distance = -1
-				break
-			}
-		}
-		fmt.Printf("Round %v: greatest distance = %v\n", i, distance)
-		if distance > total {
-			total = distance
-		}
-
+	for _, d := range loop {
+		total = max(total, d)
 	}
-	//total := max(lengths[0], lengths[1], lengths[2], lengths[3])
+	log.Printf("greatest distance = %v\n", total)
 
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): max distance %v\n\n", elapsed, total)
+	return total, nil
 }
 
-func part02() {
-	startTime := time.Now()
-	// build maze
-	lines := getInput(getNumTest(1))
-	var maze tools.Matrix
-	for _, line := range lines {
-		maze.AddLine(strings.TrimSpace(line))
+func (s *solver) Part2() (any, error) {
+	maze := s.maze
+	loop, err := findLoop(&maze)
+	if err != nil {
+		return nil, err
 	}
-	// fmt.Printf("%v\n", maze)
 
-	// find start position within maze
-	start, ok := maze.FindField('S')
-	if !ok {
-		fmt.Println("Can not find startpos!")
-		return
-	}
-	fmt.Printf("start pos: %v\n", start)
-
-	// create a copy to track coverage by the loop later
+	// create a copy to track coverage by the loop
 	var copyMaze tools.Matrix
 	line := strings.Repeat(".", maze.Cols())
 	for i := 0; i < maze.Rows(); i++ {
 		copyMaze.AddLine(line)
 	}
-	copyMaze.SetValueAtPos(start, '+')
-	// fmt.Printf("%v\n", copytools.Maze)
-
-	// replace the start character with the appropriate one and return
-	// one of the two potential next positions
-	pos := replaceStartChar(&maze, start)
-	fmt.Printf("next pos: %v\n", pos)
-
-	// assume we have gone from "last" (=start) to "pos", i.e. distance is already 1
-	var distance = 1
-	var last = start
-
-	// now continue stepping to the next position depending on
-	// char at the postion pos until you reach start again
-	for {
-		newpos, ok := move(maze, last, pos)
-		if ok {
-			copyMaze.SetValueAtPos(pos, '+')
-			last = pos
-			pos = newpos
-			distance++
-			if pos == start {
-				distance /= 2
-				break
-			}
-		}
+	for pos := range loop {
+		copyMaze.SetValueAtPos(pos, '+')
 	}
 
-	// fmt.Printf("%v\n", copytools.Maze)
-
 	// now identify inner points. Coming from the oiter border, inner points
 	// can be identified by an uneven number of "crossings" - a crossing
 	// is either a '|' or a combination of 'F*J' or 'L*7'
@@ -252,19 +176,41 @@ func part02() {
 	}
 	// fmt.Printf("%v\n", copytools.Maze)
 
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): distance = %v; inner points = %v\n\n", elapsed, distance, inner)
+	log.Printf("loop length = %v; inner points = %v\n", len(loop), inner)
+	return inner, nil
+}
+
+// find the start, replace it with the appropriate pipe and return the
+// fields of the loop with their distance from the start - it is an error
+// if the pipes starting at S do not form a closed loop
+func findLoop(maze *tools.Matrix) (map[tools.Position]int, error) {
+	start, ok := maze.FindField('S')
+	if !ok {
+		return nil, errors.New("can not find startpos")
+	}
+	log.Printf("start pos: %v\n", start)
+
+	if replaceStartChar(maze, start) == 'S' {
+		return nil, fmt.Errorf("start %v is not connected to two pipes", start)
+	}
+	neighbors := loopNeighbors(*maze)
+	loop := graph.Distances(start, neighbors)
+	for pos := range loop {
+		if len(neighbors(pos)) != 2 {
+			return nil, fmt.Errorf("loop from start %v is not closed at %v", start, pos)
+		}
+	}
+	return loop, nil
 }
 
-func replaceStartChar(maze *tools.Matrix, start tools.Position) tools.Position {
-	var pos, ret tools.Position
+func replaceStartChar(maze *tools.Matrix, start tools.Position) byte {
+	var pos tools.Position
 	var ok bool
 	var mask = 0
 	pos, ok = maze.LeftOf(start)
 	if ok {
 		c, _ := maze.ValueAtPos(pos)
 		if c == '-' || c == 'L' || c == 'F' {
-			ret = pos
 			mask += 1
 		}
 	}
@@ -272,7 +218,6 @@ func replaceStartChar(maze *tools.Matrix, start tools.Position) tools.Position {
 	if ok {
 		c, _ := maze.ValueAtPos(pos)
 		if c == '-' || c == 'J' || c == '7' {
-			ret = pos
 			mask += 2
 		}
 	}
@@ -282,7 +227,6 @@ func replaceStartChar(maze *tools.Matrix, start tools.Position) tools.Position {
 		if ok {
 			c, _ := maze.ValueAtPos(pos)
 			if c == '|' || c == 'F' || c == '7' {
-				ret = pos
 				mask += 4
 			}
 		}
@@ -291,7 +235,6 @@ func replaceStartChar(maze *tools.Matrix, start tools.Position) tools.Position {
 			if ok {
 				c, _ := maze.ValueAtPos(pos)
 				if c == '|' || c == 'L' || c == 'J' {
-					ret = pos
 					mask += 8
 				}
 			}
@@ -312,77 +255,34 @@ func replaceStartChar(maze *tools.Matrix, start tools.Position) tools.Position {
 	} else if mask == 12 {
 		repl = '|'
 	}
-	fmt.Printf("Replacing start char with '%c'\n", repl)
+	log.Printf("Replacing start char with '%c'\n", repl)
 	maze.SetValueAtPos(start, repl)
-	return ret
+	return repl
 }
 
-func move(m tools.Matrix, last tools.Position, current tools.Position) (tools.Position, bool) {
-	c, _ := m.ValueAtPos(current)
-	// fmt.Printf("Examining %v -> %v (%c) %v\n", old, this, c, corners)
-	if c == '|' {
-		if last.IsBelowOf(current) {
-			return m.AboveOf(current)
-		} else if last.IsAboveOf(current) {
-			return m.BelowOf(current)
-		} else {
-			fmt.Printf("Could not get here: %v -> %v (%c)\n", last, current, c)
-		}
-	} else if c == '-' {
-		if last.IsLeftOf(current) {
-			return m.RightOf(current)
-		} else if last.IsRightOf(current) {
-			return m.LeftOf(current)
-		} else {
-			fmt.Printf("Could not get here: %v -> %v (%c)\n", last, current, c)
-		}
-	} else if c == 'L' {
-		if last.IsAboveOf(current) {
-			return m.RightOf(current)
-		} else if last.IsRightOf(current) {
-			return m.AboveOf(current)
-		} else {
-			fmt.Printf("Could not get here: %v -> %v (%c)\n", last, current, c)
-		}
-	} else if c == 'J' {
-		if last.IsAboveOf(current) {
-			return m.LeftOf(current)
-		} else if last.IsLeftOf(current) {
-			return m.AboveOf(current)
-		} else {
-			fmt.Printf("Could not get here: %v -> %v (%c)\n", last, current, c)
-		}
-	} else if c == '7' {
-		if last.IsBelowOf(current) {
-			return m.LeftOf(current)
-		} else if last.IsLeftOf(current) {
-			return m.BelowOf(current)
-		} else {
-			fmt.Printf("Could not get here: %v -> %v (%c)\n", last, current, c)
-		}
-	} else if c == 'F' {
-		if last.IsBelowOf(current) {
-			return m.RightOf(current)
-		} else if last.IsRightOf(current) {
-			return m.BelowOf(current)
-		} else {
-			fmt.Printf("Could not get here: %v -> %v (%c)\n", last, current, c)
-		}
-	} else {
-		fmt.Printf("  Why am I here: %v -> %v (%c)\n", last, current, c)
-	}
-	return current, false
+// the two directions each pipe connects
+var pipes = map[byte][2]tools.Direction{
+	'|': {tools.North, tools.South},
+	'-': {tools.East, tools.West},
+	'L': {tools.North, tools.East},
+	'J': {tools.North, tools.West},
+	'7': {tools.South, tools.West},
+	'F': {tools.South, tools.East},
 }
 
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
+// fields connected to pos by its pipe and the pipes of the fields
+func loopNeighbors(m tools.Matrix) graph.Neighbors[tools.Position] {
+	return func(pos tools.Position) []graph.Edge[tools.Position] {
+		edges := []graph.Edge[tools.Position]{}
+		c, _ := m.ValueAtPos(pos)
+		for _, d := range pipes[c] {
+			next := pos.Step(d)
+			n, _ := m.ValueAtPos(next)
+			back := pipes[n]
+			if slices.Contains(back[:], d.Reverse()) {
+				edges = append(edges, graph.Edge[tools.Position]{To: next, Cost: 1})
+			}
 		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
+		return edges
 	}
-
 }
@@ -0,0 +1,39 @@
+package d10
+
+import (
+	"aoc23/puzzle"
+	"aoc23/puzzle/puzzletest"
+	"strings"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 8},
+		{Name: "example 1", Part: 2, Input: getNumTest(1), Want: 4},
+		{Name: "example 2", Part: 2, Input: getNumTest(2), Want: 4},
+		{Name: "example 3", Part: 2, Input: getNumTest(3), Want: 8},
+		{Name: "example 4", Part: 2, Input: getNumTest(4), Want: 10},
+	}
+	puzzletest.Run(t, 10, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 10)
+}
+
+func TestRaggedInput(t *testing.T) {
+	if err := (&solver{}).Parse(strings.NewReader("...\n..\n...")); err == nil {
+		t.Errorf("expected error for ragged input")
+	}
+}
+
+func TestOpenLoop(t *testing.T) {
+	input := ".....\n.S-7.\n.|.|.\n.L-..\n....."
+	for part := 1; part <= 2; part++ {
+		d, _ := puzzle.Get(10)
+		if _, err := d.Solve(part, strings.NewReader(input)); err == nil {
+			t.Errorf("part %v: expected error for open loop", part)
+		}
+	}
+}
@@ -12,31 +12,24 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d11
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
-	"log"
 	"regexp"
-	"time"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    11,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	part01()
-	part02()
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `...#......
@@ -50,18 +43,16 @@ var testinput = `...#......
 .......#..
 #...#.....`
 
-func part01() {
-	startTime := time.Now()
-	total := calcDistances(2)
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
+	total := calcDistances(lines, 2)
+	return total, nil
 }
 
-func part02() {
-	startTime := time.Now()
-	total := calcDistances(1000000)
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): %v\n\n", elapsed, total)
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
+	total := calcDistances(lines, 1000000)
+	return total, nil
 }
 
 func partSum(start, end int, diffs []int) int {
@@ -75,7 +66,7 @@ func partSum(start, end int, diffs []int) int {
 	return val
 }
 
-func calcDistances(replace int) int {
+func calcDistances(lines []string, replace int) int {
 
 	var galaxies []tools.Position // all galaxies
 	var cols, rows int            // size of the universe (same values)
@@ -83,7 +74,6 @@ func calcDistances(replace int) int {
 	var yDist []int               // actual distance per row
 
 	re := regexp.MustCompile(`#`)
-	lines := getInput()
 	cnt := 0
 	for _, line := range lines {
 		if cnt == 0 {
@@ -135,15 +125,3 @@ func calcDistances(replace int) int {
 	}
 	return total
 }
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-}
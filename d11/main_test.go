@@ -0,0 +1,18 @@
+package d11
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 374},
+		{Part: 2, Input: testinput, Want: 82000210},
+	}
+	puzzletest.Run(t, 11, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 11)
+}
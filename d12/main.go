@@ -10,47 +10,35 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d12
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
 	"fmt"
 	"log"
 	"regexp"
 	"strings"
-	"time"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-var part1only = flag.Bool("1", false, "run part 1 only")
-var part2only = flag.Bool("2", false, "run part 2 only")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    12,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	if !*part2only {
-		part01()
-	}
-	if !*part1only {
-		part02()
-	}
+type solver struct {
+	puzzle.Input
 }
 
 type Pump struct {
-	val      int // value
-	length   int // value + 1, as we need a "."
-	front    int // cumulated length of all before
-	back     int // cumulated length of all after
-	re       *regexp.Regexp
-	valcache map[int]int
+	val    int // value
+	length int // value + 1, as we need a "."
+	front  int // cumulated length of all before
+	back   int // cumulated length of all after
+	re     *regexp.Regexp
 }
 
 func (p Pump) String() string {
@@ -69,7 +57,6 @@ func makePumps(vals []int) []Pump {
 		p.front = tools.SumInts(vals[0:i]) + i // there are i elements before i
 		p.back = tools.SumInts(vals[i+1:]) + (len(vals) - (i + 1))
 		p.re = regexp.MustCompile(fmt.Sprintf(`[#\?]{%v}[\?\.]`, p.val))
-		p.valcache = make(map[int]int)
 		pumps[i] = p
 	}
 	return pumps
@@ -82,17 +69,39 @@ var testinput = `???.### 1,1,3
 ????.######..#####. 1,6,5
 ?###???????? 3,2,1`
 
-func numMatches(pumps []Pump, idx int, line string) int {
+// position in the search: index of the next pump to place and offset in the line
+type state struct {
+	pump   int
+	offset int
+}
 
-	// buf := strings.Repeat("  ", idx)
+// memo for numMatches on the pumps and line of the current input line
+type matcher struct {
+	pumps []Pump
+	line  string
+	memo  *tools.Memo[state, int]
+}
 
-	p := pumps[0]
+func newMatcher() *matcher {
+	m := &matcher{}
+	m.memo = tools.Memoize(func(recurse func(state) int, st state) int {
+		return numMatches(recurse, m.pumps[st.pump:], st, m.line[st.offset:])
+	})
+	return m
+}
 
-	// check if cached
-	v, ok := p.valcache[idx]
-	if ok {
-		return v
-	}
+// number of arrangements of the pumps in line
+func (m *matcher) count(pumps []Pump, line string) int {
+	m.pumps, m.line = pumps, line
+	m.memo.Reset()
+	return m.memo.Get(state{0, 0})
+}
+
+// pumps and line are the remaining ones at st, recurse is called for
+// the remaining pumps/line after placing (or shifting) the first pump
+func numMatches(recurse func(state) int, pumps []Pump, st state, line string) int {
+
+	p := pumps[0]
 
 	// fmt.Printf("%vExamining %v in line %v\n", buf, p.val, line)
 	if p.length+p.back > len(line) {
@@ -120,7 +129,7 @@ func numMatches(pumps []Pump, idx int, line string) int {
 			return 0
 		}
 		// fmt.Printf("%vCalling for remaining (%v) pumps in remaining string '%v'\n", buf, len(pumps)-1, line[newstart:])
-		val := numMatches(pumps[1:], idx+newstart, line[newstart:])
+		val := recurse(state{st.pump + 1, st.offset + newstart})
 		if val != -1 {
 			retval += val
 		}
@@ -131,13 +140,13 @@ func numMatches(pumps []Pump, idx int, line string) int {
 	if byte(line[m[0]]) == '?' && byte(line[m[1]-1]) != '.' && m[0]+1+p.back < remain {
 		// yes, there is an option, follow that path instead
 		// fmt.Printf("%vTrying to shift (%v) one position in remaining string '%v'\n", buf, p.val, line[m[0]:])
-		cnt := numMatches(pumps, idx+m[0]+1, line[m[0]+1:])
+		cnt := recurse(state{st.pump, st.offset + m[0] + 1})
 		if cnt != -1 {
 			retval += cnt
 		}
 	} else if !strings.Contains(line[m[0]:m[1]], "#") && newstart+p.back < remain {
 		// fmt.Printf("%vTrying to shift (%v) at end of ??? position '%v'\n", buf, p.val, line[m[0]:])
-		cnt := numMatches(pumps, idx+newstart, line[newstart:])
+		cnt := recurse(state{st.pump, st.offset + newstart})
 		if cnt != -1 {
 			retval += cnt
 		}
@@ -155,40 +164,37 @@ func numMatches(pumps []Pump, idx int, line string) int {
 		retval += 1
 	}
 
-	// cache value for next time
-	p.valcache[idx] = retval
-
 	// fmt.Printf("%vReturning %v\n", buf, retval)
 	return retval
 }
 
-func part01() {
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
 
-	startTime := time.Now()
 	cnt := 0
 	total := 0
-	lines := getInput()
+	m := newMatcher()
 	for _, line := range lines {
 		// fmt.Printf("%v (%v): %v\n", cnt, len(line), line)
 		parts := strings.Split(line, " ")
 		vals := tools.ReadInts(parts[1])
 		pumps := makePumps(vals)
 		// fmt.Printf("%v\n", pumps)
-		options := numMatches(pumps, 0, parts[0]+".")
+		options := m.count(pumps, parts[0]+".")
 		// fmt.Printf("%v: '%v' - %v arrangement(s)\n", cnt, line, options)
 		// fmt.Println("==============================================================")
 		total += options
 		cnt++
 	}
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
+	log.Printf("cache: %v\n", m.memo)
+	return total, nil
 }
 
-func part02() {
-	startTime := time.Now()
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
 	cnt := 0
 	total := 0
-	lines := getInput()
+	m := newMatcher()
 	for _, line := range lines {
 		// fmt.Printf("%v (%v): %v\n", cnt, len(line), line)
 		parts := strings.Split(line, " ")
@@ -204,25 +210,12 @@ func part02() {
 		// for i, p := range pumps {
 		// 	fmt.Printf("%v: %v\n", i, p)
 		// }
-		options := numMatches(pumps, 0, strip+".")
+		options := m.count(pumps, strip+".")
 		// fmt.Printf("%v: '%v' - %v arrangement(s)\n", cnt, line, options)
 		// fmt.Println("==============================================================")
 		total += options
 		cnt++
 	}
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): %v\n\n", elapsed, total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	log.Printf("cache: %v\n", m.memo)
+	return total, nil
 }
@@ -0,0 +1,18 @@
+package d12
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 21},
+		{Part: 2, Input: testinput, Want: 525152},
+	}
+	puzzletest.Run(t, 12, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 12)
+}
@@ -9,36 +9,41 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d13
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
 	"fmt"
+	"io"
 	"log"
-	"time"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-var part1only = flag.Bool("1", false, "run part 1 only")
-var part2only = flag.Bool("2", false, "run part 2 only")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    13,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	patterns []tools.Matrix
+}
 
-	if !*part2only {
-		part01()
+func (s *solver) Parse(r io.Reader) error {
+	patterns, err := tools.ReadBlocks(r)
+	if err != nil {
+		return err
 	}
-	if !*part1only {
-		part02()
+	for i, p := range patterns {
+		m, err := tools.ParseMatrix(p)
+		if err != nil {
+			return fmt.Errorf("pattern %v: %w", i+1, err)
+		}
+		s.patterns = append(s.patterns, m)
 	}
+	return nil
 }
 
 var testinput = `#.##..##.
@@ -132,16 +137,6 @@ func getMirrorIdx(pattern []string, withFlipping bool) int {
 	return 0
 }
 
-func transpose(s []string) []string {
-	ret := make([]string, len(s[0]))
-	for i := range s {
-		for j := range s[i] {
-			ret[j] = ret[j] + string(s[i][j])
-		}
-	}
-	return ret
-}
-
 func numDiff(s1, s2 string) (int, int) {
 	if len(s1) != len(s2) {
 		return -1, -1
@@ -159,66 +154,38 @@ func numDiff(s1, s2 string) (int, int) {
 	return num, first
 }
 
-func process(withFlipping bool) int {
-	cnt := 0
+func process(patterns []tools.Matrix, withFlipping bool) int {
 	total := 0
-	lines := getInput()
-	lines = append(lines, "")
-	pattern := []string{}
-	for _, line := range lines {
-		// log.Printf("Processing line %v with len %v\n", cnt, len(line))
-		if len(line) == 0 {
-			// process
-			cnt++
-			val := 0
-			transposed := ""
+	for cnt, m := range patterns {
+		pattern := m.Lines()
+		val := 0
+		transposed := ""
+		val = getMirrorIdx(pattern, withFlipping)
+		if val != 0 {
+			total += 100 * val
+		} else {
+			// transpose pattern
+			// fmt.Println("Transposing...")
+			pattern = m.Transpose().Lines()
 			val = getMirrorIdx(pattern, withFlipping)
 			if val != 0 {
-				total += 100 * val
-			} else {
-				// transpose pattern
-				// fmt.Println("Transposing...")
-				pattern = transpose(pattern)
-				val = getMirrorIdx(pattern, withFlipping)
-				if val != 0 {
-					transposed = "(transposed)"
-					total += val
-				}
+				transposed = "(transposed)"
+				total += val
 			}
-			fmt.Printf("%v: val %v %v\n", cnt, val, transposed)
-			pattern = []string{}
-		} else {
-			pattern = append(pattern, line)
 		}
+		log.Printf("%v: val %v %v\n", cnt+1, val, transposed)
 	}
 	return total
 }
 
-func part01() {
-	startTime := time.Now()
-	total := process(false)
+func (s *solver) Part1() (any, error) {
+	total := process(s.patterns, false)
 	// total = cnt
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
+	return total, nil
 }
 
-func part02() {
-	startTime := time.Now()
-	total := process(true)
+func (s *solver) Part2() (any, error) {
+	total := process(s.patterns, true)
 	// total = cnt
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): %v\n\n", elapsed, total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	return total, nil
 }
@@ -0,0 +1,18 @@
+package d13
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 405},
+		{Part: 2, Input: testinput, Want: 400},
+	}
+	puzzletest.Run(t, 13, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 13)
+}
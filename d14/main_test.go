@@ -0,0 +1,18 @@
+package d14
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 136},
+		{Part: 2, Input: testinput, Want: 64},
+	}
+	puzzletest.Run(t, 14, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 14)
+}
@@ -13,37 +13,25 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d15
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
 	"fmt"
-	"log"
 	"strings"
-	"time"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-var part1only = flag.Bool("1", false, "run part 1 only")
-var part2only = flag.Bool("2", false, "run part 2 only")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    15,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	if !*part2only {
-		part01()
-	}
-	if !*part1only {
-		part02()
-	}
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `rn=1,cm-,qp=3,cm=2,qp-,pc=4,ot=9,ab=5,pc-,pc=6,ot=7`
@@ -125,19 +113,15 @@ func calcHash(token string) int {
 	return val
 }
 
-func part01() {
-	startTime := time.Now()
-
-	lines := getInput()
-
+func (s *solver) Part1() (any, error) {
+	lines := s.Lines
 	total := 0
 	parts := strings.Split(lines[0], ",")
 	for _, p := range parts {
 		val := calcHash(p)
 		total += val
 	}
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
+	return total, nil
 }
 
 func splitToken(token string) (string, int) {
@@ -145,15 +129,12 @@ func splitToken(token string) (string, int) {
 		return token[0 : len(token)-1], -1
 	} else {
 		parts := strings.Split(token, "=")
-		return parts[0], tools.Str2Int(parts[1])
+		return parts[0], tools.MustAtoi(parts[1])
 	}
 }
 
-func part02() {
-	startTime := time.Now()
-
-	lines := getInput()
-
+func (s *solver) Part2() (any, error) {
+	lines := s.Lines
 	boxes := make([]*box, 256)
 	for i := 0; i < 256; i++ {
 		b := makeBox(fmt.Sprintf("Box %v", i))
@@ -179,19 +160,5 @@ func part02() {
 	for i, b := range boxes {
 		total += b.valuate(i)
 	}
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): %v\n\n", elapsed, total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+	return total, nil
 }
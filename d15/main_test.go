@@ -0,0 +1,18 @@
+package d15
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 1320},
+		{Part: 2, Input: testinput, Want: 145},
+	}
+	puzzletest.Run(t, 15, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 15)
+}
@@ -12,36 +12,33 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d16
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
-	"fmt"
-	"log"
-	"time"
+	"io"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-var part1only = flag.Bool("1", false, "run part 1 only")
-var part2only = flag.Bool("2", false, "run part 2 only")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    16,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	grid tools.Matrix
+}
 
-	if !*part2only {
-		part01()
-	}
-	if !*part1only {
-		part02()
+func (s *solver) Parse(r io.Reader) error {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return err
 	}
+	s.grid, err = tools.ParseMatrix(lines)
+	return err
 }
 
 var testinput = `.|...\....
@@ -56,62 +53,42 @@ var testinput = `.|...\....
 ..//.|....`
 
 const (
-	North byte = 1
-	East  byte = 2
-	West  byte = 4
-	South byte = 8
+	North = tools.North
+	East  = tools.East
+	South = tools.South
+	West  = tools.West
 )
 
+// directions in which a beam already passed a field
+type visits [8]bool
+
 type Beam struct {
 	pos       tools.Position
-	direction byte
+	direction tools.Direction
 }
 
 func (b *Beam) advance(grid *tools.Matrix) bool {
-	if b.direction == North {
-		if b.pos[1] == 0 {
-			return false
-		} else {
-			b.pos[1]--
-			return true
-		}
-	} else if b.direction == East {
-		if b.pos[0] == grid.Cols()-1 {
-			return false
-		} else {
-			b.pos[0]++
-			return true
-		}
-	} else if b.direction == South {
-		if b.pos[1] == grid.Rows()-1 {
-			return false
-		} else {
-			b.pos[1]++
-			return true
-		}
-	} else if b.direction == West {
-		if b.pos[0] == 0 {
-			return false
-		} else {
-			b.pos[0]--
-			return true
-		}
+	next := b.pos.Step(b.direction)
+	if !grid.InBounds(next) {
+		return false
 	}
-	return false
+	b.pos = next
+	return true
 }
 
-func alreadyVisited(b *Beam, chk *tools.Matrix) bool {
-	c, _ := chk.ValueAtPos(b.pos)
-	if c&b.direction > 0 {
+func alreadyVisited(b *Beam, chk *tools.Grid[visits]) bool {
+	v := chk.At(b.pos)
+	if v[b.direction] {
 		return true
 	} else {
-		chk.SetValueAtPos(b.pos, c+b.direction)
+		v[b.direction] = true
+		chk.Set(b.pos, v)
 		return false
 	}
 }
 
 func followBeam(startBeam *Beam, grid *tools.Matrix) int {
-	check := tools.NewMatrix(grid.Rows(), grid.Cols())
+	check := tools.NewGrid[visits](grid.Rows(), grid.Cols())
 
 	var allBeams tools.Stack[*Beam]
 	allBeams.Push(startBeam)
@@ -124,7 +101,7 @@ func followBeam(startBeam *Beam, grid *tools.Matrix) int {
 			b, _ = allBeams.Pop()
 		}
 	}
-	return check.CountNonZero()
+	return check.Count(func(v visits) bool { return v != visits{} })
 }
 
 func run(grid *tools.Matrix, part int) int {
@@ -156,7 +133,7 @@ func run(grid *tools.Matrix, part int) int {
 	}
 }
 
-func processBeam(b *Beam, grid *tools.Matrix, chk *tools.Matrix, bs *tools.Stack[*Beam]) bool {
+func processBeam(b *Beam, grid *tools.Matrix, chk *tools.Grid[visits], bs *tools.Stack[*Beam]) bool {
 
 	// pos := b.pos
 	ok := b.advance(grid)
@@ -183,76 +160,29 @@ func processBeam(b *Beam, grid *tools.Matrix, chk *tools.Matrix, bs *tools.Stack
 			bs.Push(&nb)
 		}
 	} else if c == '\\' {
-		switch b.direction {
-		case North:
-			b.direction = West
-		case East:
-			b.direction = South
-		case South:
-			b.direction = East
-		case West:
-			b.direction = North
+		// vertical beams turn left, horizontal ones right
+		if b.direction == North || b.direction == South {
+			b.direction = b.direction.TurnLeft()
+		} else {
+			b.direction = b.direction.TurnRight()
 		}
 	} else if c == '/' {
-		switch b.direction {
-		case North:
-			b.direction = East
-		case East:
-			b.direction = North
-		case South:
-			b.direction = West
-		case West:
-			b.direction = South
+		// and the other way round
+		if b.direction == North || b.direction == South {
+			b.direction = b.direction.TurnRight()
+		} else {
+			b.direction = b.direction.TurnLeft()
 		}
 	}
 	return true
 }
 
-func part01() {
-	startTime := time.Now()
-	cnt := 0
-	total := 0
-
-	var grid tools.Matrix
-	lines := getInput()
-	for _, line := range lines {
-		grid.AddLine(line)
-		cnt++
-	}
-	// fmt.Printf("*** Grid ***\n%v", grid)
-
-	total = run(&grid, 1)
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
+func (s *solver) Part1() (any, error) {
+	total := run(&s.grid, 1)
+	return total, nil
 }
 
-func part02() {
-	startTime := time.Now()
-	cnt := 0
-	total := 0
-
-	var grid tools.Matrix
-	lines := getInput()
-	for _, line := range lines {
-		grid.AddLine(line)
-		cnt++
-	}
-	// fmt.Printf("*** Grid ***\n%v", grid)
-
-	total = run(&grid, 2)
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): %v\n\n", elapsed, total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+func (s *solver) Part2() (any, error) {
+	total := run(&s.grid, 2)
+	return total, nil
 }
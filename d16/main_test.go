@@ -0,0 +1,25 @@
+package d16
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"strings"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 46},
+		{Part: 2, Input: testinput, Want: 51},
+	}
+	puzzletest.Run(t, 16, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 16)
+}
+
+func TestRaggedInput(t *testing.T) {
+	if err := (&solver{}).Parse(strings.NewReader("...\n..\n...")); err == nil {
+		t.Errorf("expected error for ragged input")
+	}
+}
@@ -5,41 +5,44 @@
  * with maps and lists. As usual, parsing with regexp took quite some time - next
  * time I'll do it via read-by-character. ;-)
  * Part 2 was more tricky using recursion. Took me some time to build the
- * correct sum...
+ * correct sum... Now the boxes of ratings are split without recursion.
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package d19
 
 import (
+	"aoc23/puzzle"
 	"aoc23/tools"
-	"flag"
+	"aoc23/tools/interval"
 	"fmt"
+	"io"
 	"log"
 	"regexp"
-	"time"
+	"strings"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-var part1only = flag.Bool("1", false, "run part 1 only")
-var part2only = flag.Bool("2", false, "run part 2 only")
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    19,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+type solver struct {
+	workflows WorkflowMap
+	parts     []*Part
+}
 
-	if !*part2only {
-		part01()
-	}
-	if !*part1only {
-		part02()
-	}
+func (s *solver) Parse(r io.Reader) error {
+	s.workflows = make(WorkflowMap)
+	return tools.NewSectionParser().
+		Block(`^[a-z]+\{`, func(_, lines []string, start int) error {
+			return loadWorkflows(s.workflows, lines, start)
+		}).
+		Block(`^\{`, tools.Each(&s.parts, makePart)).
+		ParseReader(r)
 }
 
 // l:= list of workflows that have only R
@@ -120,142 +123,110 @@ func apply(in *Part, wf *Workflow) (string, bool) {
 	return wf.fallback, true
 }
 
-type PartRange map[string][2]int
+// the four categories, in the order of the dimensions of a box of parts
+const categories = "xmas"
 
-func (pr PartRange) copy() PartRange {
-	ret := make(PartRange)
-	for k, v := range pr {
-		ret[k] = v
-	}
-	return ret
-}
-
-func (pr PartRange) value() int {
-	val := 1
-	for _, v := range pr {
-		val *= v[1] - v[0] + 1
-	}
-	return val
+// box of parts that still needs to go through a workflow
+type pendingBox struct {
+	box      interval.Box
+	workflow string
 }
 
-func applyRange(pr PartRange, wfm *WorkflowMap, wfname string, totals int, idx int) int {
+// all boxes of parts accepted by the workflows, starting with box in workflow "in"
+func acceptedBoxes(box interval.Box, wfm WorkflowMap) ([]interval.Box, error) {
+	accepted := []interval.Box{}
+	var pending tools.Stack[pendingBox]
+	pending.Push(pendingBox{box, "in"})
 
-	// buf := strings.Repeat("  ", idx)
-	sum := 0
-
-	wf := (*wfm)[wfname]
-	// fmt.Printf("%vApplying wf '%v' (%v) with PR %v (totals: %v)\n", buf, wfname, wf, pr, totals)
-
-	for _, r := range wf.rules {
-		rng := pr[r.param]
-		if rng[0] <= r.val && r.val <= rng[1] {
-			// we need to split it up
-			var r1, r2 [2]int
+	for pb, ok := pending.Pop(); ok; pb, ok = pending.Pop() {
+		wf, ok := wfm[pb.workflow]
+		if !ok {
+			return nil, fmt.Errorf("unknown workflow %q", pb.workflow)
+		}
+		// handle the part of the box a rule (or the fallback) applies to
+		handle := func(b interval.Box, result string) {
+			if result == "A" {
+				accepted = append(accepted, b)
+			} else if result != "R" {
+				pending.Push(pendingBox{b, result})
+			}
+		}
+		rest := pb.box
+		for _, r := range wf.rules {
+			dim := strings.Index(categories, r.param)
+			var affected interval.Box
 			if r.op == "<" {
-				r1 = [2]int{rng[0], r.val - 1} // affected by rule
-				r2 = [2]int{r.val, rng[1]}     // not affected by rule, need to jump to next
+				affected, rest = rest.SplitAt(dim, r.val)
 			} else { // >
-				r2 = [2]int{rng[0], r.val}     // not affected by rule, need to jump to next
-				r1 = [2]int{r.val + 1, rng[1]} // affected by rule
+				rest, affected = rest.SplitAt(dim, r.val+1)
 			}
-
-			pr[r.param] = r2
-
-			// npr is the range affected by this rule
-			npr := pr.copy()
-			npr[r.param] = r1
-			if r.result == "A" {
-				// sum up range values
-				v := npr.value()
-				// fmt.Printf("%v  %v: + Adding up %v: %v\n", buf, wfname, npr, v)
-				sum += v
-			} else if r.result == "R" {
-				// fmt.Printf("%v  %v: - Ignoring %v\n", buf, wfname, npr)
-				// ignore range
-			} else {
-				sum += applyRange(npr, wfm, r.result, totals, idx+1)
+			if !affected.Empty() {
+				handle(affected, r.result)
+			}
+			if rest.Empty() {
+				break
 			}
-
-			// next rule
+		}
+		if !rest.Empty() {
+			handle(rest, wf.fallback)
 		}
 	}
-
-	// all rules passed, apply last PartRange
-	if wf.fallback == "A" {
-		v := pr.value()
-		// fmt.Printf("%v  %v: * Adding up %v: %v\n", buf, wfname, pr, v)
-		sum += v
-	} else if wf.fallback == "R" {
-		// fmt.Printf("%v  %v: - Ignoring %v\n", buf, wfname, pr)
-	} else {
-		v := applyRange(pr, wfm, wf.fallback, totals, idx)
-		sum += v
-	}
-	return sum
+	return accepted, nil
 }
 
-func makeWorkflow(s string) *Workflow {
+var (
+	reWorkflow = regexp.MustCompile(`^([a-z]+)\{((?:[xmas][<>]\d+:[a-zA-Z]+,)*)([a-zA-Z]+)\}$`)
+	reRule     = regexp.MustCompile(`([xmas])([<>])(\d+):([a-zA-Z]+)`)
+	rePart     = regexp.MustCompile(`([a-z])=(\d+)`)
+)
+
+func makeWorkflow(s string) (*Workflow, error) {
 	wf := Workflow{}
-	re1 := regexp.MustCompile(`([a-z]+)\{((?:[a-z]+[<>]\d+:[a-zA-Z]+,)+)([a-zA-Z]+)\}`)
-	elems := re1.FindAllStringSubmatch(s, -1)
-	wf.name = elems[0][1]
-	wf.fallback = elems[0][3]
-	re2 := regexp.MustCompile(`([a-z]+)([<>])(\d+):([a-zA-Z]+)`)
-	elems2 := re2.FindAllStringSubmatch(elems[0][2], -1)
+	elems := reWorkflow.FindStringSubmatch(s)
+	if elems == nil {
+		return nil, fmt.Errorf("invalid workflow %q", s)
+	}
+	wf.name = elems[1]
+	wf.fallback = elems[3]
+	elems2 := reRule.FindAllStringSubmatch(elems[2], -1)
 	wf.rules = make([]Rule, len(elems2))
 	for i := range elems2 {
-		// r := Rule{}
 		wf.rules[i].param = elems2[i][1]
 		wf.rules[i].op = elems2[i][2]
 		wf.rules[i].val = tools.Str2Int(elems2[i][3])
 		wf.rules[i].result = elems2[i][4]
-		// wf.rules[i] = r
 	}
-	return &wf
+	return &wf, nil
 }
 
-func loadWorkflows(lines []string) WorkflowMap {
-	workflows := WorkflowMap(make(map[string]*Workflow))
-	for _, line := range lines {
-		if len(line) == 0 {
-			break
+// lines start at line number start of the input
+func loadWorkflows(workflows WorkflowMap, lines []string, start int) error {
+	for i, line := range lines {
+		wf, err := makeWorkflow(line)
+		if err != nil {
+			return &tools.LineError{Line: start + i, Err: err}
 		}
-		wf := makeWorkflow(line)
 		workflows[wf.name] = wf
 	}
-	return workflows
+	return nil
 }
 
-func makePart(s string) *Part {
-	re := regexp.MustCompile(`([a-z])=(\d+)`)
-	parts := re.FindAllStringSubmatch(s, -1)
+func makePart(s string) (*Part, error) {
+	parts := rePart.FindAllStringSubmatch(s, -1)
+	if parts == nil {
+		return nil, fmt.Errorf("invalid part %q", s)
+	}
 	input := Part(make(map[string]int))
 	for i := range parts {
 		input[parts[i][1]] = tools.Str2Int(parts[i][2])
 	}
-	return &input
-}
-
-func loadParts(lines []string) []*Part {
-	allParts := []*Part{}
-	for _, line := range lines {
-		allParts = append(allParts, makePart(line))
-	}
-	return allParts
+	return &input, nil
 }
 
-func part01() {
-	startTime := time.Now()
+func (s *solver) Part1() (any, error) {
 	total := 0
-	lines := getInput()
-	var breakLine int
-	for len(lines[breakLine]) > 0 {
-		breakLine++
-	}
-
-	workflows := loadWorkflows(lines)
-	parts := loadParts(lines[breakLine+1:])
-	fmt.Printf("Read %v workflows, %v parts\n", len(workflows), len(parts))
+	workflows, parts := s.workflows, s.parts
+	log.Printf("Read %v workflows, %v parts\n", len(workflows), len(parts))
 
 	for _, in := range parts {
 		val := "in"
@@ -276,44 +247,22 @@ func part01() {
 		}
 	}
 
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
+	return total, nil
 }
 
-func part02() {
-	startTime := time.Now()
-	lines := getInput()
-	workflows := loadWorkflows(lines)
-	fmt.Printf("Read %v workflows\n", len(workflows))
+func (s *solver) Part2() (any, error) {
+	workflows := s.workflows
+	log.Printf("Read %v workflows\n", len(workflows))
 
-	startRange := PartRange{
-		"x": [2]int{1, 4000},
-		"m": [2]int{1, 4000},
-		"a": [2]int{1, 4000},
-		"s": [2]int{1, 4000},
+	// all ratings from 1 to 4000 in all four categories
+	start := interval.Cube(len(categories), interval.Range{Start: 1, End: 4001})
+	accepted, err := acceptedBoxes(start, workflows)
+	if err != nil {
+		return nil, err
 	}
+	log.Printf("%v accepted boxes\n", len(accepted))
 
-	// test applyRange
-	// wf := workflows["px"]
-	// prl := applyRange(pr, wf)
-	// fmt.Printf("Workflow: %v\n", wf)
-	// fmt.Printf("Result: %v\n", prl)
-
-	wfname := "in"
-	total := applyRange(startRange, &workflows, wfname, 0, 0)
+	total := interval.UnionVolume(accepted)
 
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 02 (%v): %v\n\n", elapsed, total)
-}
-
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
+	return total, nil
 }
@@ -0,0 +1,36 @@
+package d19
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"aoc23/tools"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Part: 1, Input: testinput, Want: 19114},
+		{Part: 2, Input: testinput, Want: 167409079868000},
+	}
+	puzzletest.Run(t, 19, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 19)
+}
+
+func TestInvalidCategory(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		line  int
+	}{
+		{"in{q<5:A,R}\n\n{x=1,m=2,a=3,s=4}", 1},
+		{"px{a<5:A,R}\nin{ma<5:A,R}\n\n{x=1,m=2,a=3,s=4}", 2},
+	} {
+		var le *tools.LineError
+		if err := (&solver{}).Parse(strings.NewReader(tc.input)); !errors.As(err, &le) || le.Line != tc.line {
+			t.Errorf("%q: got %v, want error in line %v", tc.input, err, tc.line)
+		}
+	}
+}
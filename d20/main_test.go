@@ -0,0 +1,18 @@
+package d20
+
+import (
+	"aoc23/puzzle/puzzletest"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	tests := []puzzletest.Case{
+		{Name: "example 1", Part: 1, Input: testinput1, Want: 32000000},
+		{Name: "example 2", Part: 1, Input: testinput2, Want: 11687500},
+	}
+	puzzletest.Run(t, 20, tests)
+}
+
+func TestAnswers(t *testing.T) {
+	puzzletest.RunAnswers(t, 20)
+}
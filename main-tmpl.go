@@ -1,3 +1,5 @@
+//go:build ignore
+
 /*
  * Day DD of AoC 2023
  *
@@ -5,78 +7,39 @@
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
-package main
+package dDD
 
 import (
-	"aoc23/tools"
-	"flag"
-	"fmt"
+	"aoc23/puzzle"
 	"log"
-	"time"
 )
 
-var TESTMODE = true
-var nt = flag.Bool("nt", false, "exec no test mode")
-var inputfile = flag.String("f", "input.txt", "name of input file")
-var part1only = flag.Bool("1", false, "run part 1 only")
-var part2only = flag.Bool("2", false, "run part 2 only")
-
-func main() {
-	flag.Parse()
-	if *nt {
-		TESTMODE = false
-	}
-	log.SetPrefix("  ")
-	log.SetFlags(0)
+func init() {
+	puzzle.Register(puzzle.Day{
+		Number:    DD,
+		TestInput: testinput,
+		New:       func() puzzle.Solver { return &solver{} },
+	})
+}
 
-	if !*part2only {
-		part01()
-	}
-	if !*part1only {
-		part02()
-	}
+type solver struct {
+	puzzle.Input
 }
 
 var testinput = `xxx
 `
 
-func part01() {
-	startTime := time.Now()
+func (s *solver) Part1() (any, error) {
 	cnt := 0
 	total := 0
-	lines := getInput()
-	for _, line := range lines {
+	for _, line := range s.Lines {
 		log.Printf("Processing line %v with len %v\n", cnt, len(line))
 		cnt++
 	}
 	total = cnt
-	elapsed := time.Since(startTime)
-	fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
-}
-
-func part02() {
-	// startTime := time.Now()
-	// cnt := 0
-	// total := 0
-	// lines := getInput()
-	// for _, line := range lines {
-	// 	log.Printf("Processing line %v with len %v\n", cnt, len(line))
-	// 	total++
-	// }
-	// elapsed := time.Since(startTime)
-	// fmt.Printf("Result part 01 (%v): %v\n\n", elapsed, total)
-	fmt.Println("Part 02 not implemented yet")
+	return total, nil
 }
 
-func getInput(inputs ...string) []string {
-	if TESTMODE {
-		input := testinput
-		if len(inputs) > 0 {
-			input = inputs[0]
-		}
-		return tools.ReadInputString(input)
-	} else {
-		return tools.ReadInputFile(*inputfile)
-	}
-
+func (s *solver) Part2() (any, error) {
+	return nil, puzzle.ErrNotImplemented
 }
@@ -0,0 +1,117 @@
+/*
+ * Puzzle registry
+ *
+ * Every day registers a Solver here (see init() in the day's main.go),
+ * so that the runner in cmd/aoc can dispatch to any day by its number
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package puzzle
+
+import (
+	"aoc23/tools"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// A Solver parses the input of a day and calculates the results of both
+// parts. A fresh solver is created for every part (parts may modify
+// the parsed data)
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1() (any, error)
+	Part2() (any, error)
+}
+
+// Solvers of days with an interesting input structure can implement
+// Visualizer to export it (after Parse) in Graphviz DOT format
+type Visualizer interface {
+	WriteDOT(w io.Writer) error
+}
+
+// ErrNotImplemented is returned by parts that are not solved yet
+var ErrNotImplemented = errors.New("not implemented yet")
+
+// Input can be embedded by solvers that just work on the input lines
+type Input struct {
+	Lines []string
+}
+
+func (in *Input) Parse(r io.Reader) error {
+	lines, err := tools.ReadLines(r)
+	if err != nil {
+		return err
+	}
+	in.Lines = lines
+	return nil
+}
+
+type Day struct {
+	Number     int
+	TestInput  string // example input from the puzzle description
+	TestInput2 string // optional, if part 2 uses a different example
+	New        func() Solver
+}
+
+var days = make(map[int]Day)
+
+// Register a day - to be called from the init() function of the day
+func Register(d Day) {
+	if _, ok := days[d.Number]; ok {
+		panic(fmt.Sprintf("day %02d registered twice", d.Number))
+	}
+	days[d.Number] = d
+}
+
+// Get the day with the given number
+func Get(num int) (Day, bool) {
+	d, ok := days[num]
+	return d, ok
+}
+
+// Numbers of all registered days, sorted
+func Numbers() []int {
+	nums := make([]int, 0, len(days))
+	for n := range days {
+		nums = append(nums, n)
+	}
+	slices.Sort(nums)
+	return nums
+}
+
+// Return the example input for the given part
+func (d Day) Example(part int) string {
+	if part == 2 && len(d.TestInput2) > 0 {
+		return d.TestInput2
+	}
+	return d.TestInput
+}
+
+// Solve one part of the day with a fresh solver reading from r
+func (d Day) Solve(part int, r io.Reader) (any, error) {
+	s := d.New()
+	if err := s.Parse(r); err != nil {
+		return nil, fmt.Errorf("parsing input: %w", err)
+	}
+	switch part {
+	case 1:
+		return s.Part1()
+	case 2:
+		return s.Part2()
+	default:
+		return nil, fmt.Errorf("invalid part %v", part)
+	}
+}
+
+// Solve one part of the day using its example input
+func (d Day) SolveExample(part int) (any, error) {
+	return d.Solve(part, strings.NewReader(d.Example(part)))
+}
+
+// Default directory of a day, e.g. "d03"
+func Dir(num int) string {
+	return fmt.Sprintf("d%02d", num)
+}
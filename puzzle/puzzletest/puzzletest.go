@@ -0,0 +1,117 @@
+/*
+ * Test helpers for the days
+ *
+ * Each day has a table driven test running its examples through the
+ * solver, and optionally checks the real input against known answers
+ * stored in testdata/answers.json, e.g.
+ *
+ *	{"part1": 12345, "part2": "abc"}
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package puzzletest
+
+import (
+	"aoc23/puzzle"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// One example to run through a solver
+type Case struct {
+	Name  string
+	Part  int
+	Input string
+	Want  any
+}
+
+// Run all examples of a day - results are compared by their string
+// representation, so Want can be given as int, string, ...
+func Run(t *testing.T, day int, cases []Case) {
+	t.Helper()
+	d, ok := puzzle.Get(day)
+	if !ok {
+		t.Fatalf("day %02d is not registered", day)
+	}
+	quiet()
+	for _, c := range cases {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("part %v", c.Part)
+		}
+		t.Run(name, func(t *testing.T) {
+			got, err := d.Solve(c.Part, strings.NewReader(c.Input))
+			if err != nil {
+				t.Fatalf("part %v: unexpected error: %v", c.Part, err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(c.Want) {
+				t.Errorf("part %v: got %v, want %v", c.Part, got, c.Want)
+			}
+		})
+	}
+}
+
+type answers struct {
+	Part1 any `json:"part1"`
+	Part2 any `json:"part2"`
+}
+
+// Check the real input (input.txt) against testdata/answers.json.
+// The test is skipped, if one of the files does not exist
+func RunAnswers(t *testing.T, day int) {
+	t.Helper()
+	d, ok := puzzle.Get(day)
+	if !ok {
+		t.Fatalf("day %02d is not registered", day)
+	}
+	data, err := os.ReadFile(filepath.Join("testdata", "answers.json"))
+	if os.IsNotExist(err) {
+		t.Skip("no testdata/answers.json")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var want answers
+	if err := dec.Decode(&want); err != nil {
+		t.Fatalf("reading testdata/answers.json: %v", err)
+	}
+	if _, err := os.Stat("input.txt"); os.IsNotExist(err) {
+		t.Skip("no input.txt")
+	}
+	quiet()
+
+	for part, w := range []any{want.Part1, want.Part2} {
+		if w == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("part %v", part+1), func(t *testing.T) {
+			f, err := os.Open("input.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			got, err := d.Solve(part+1, f)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(w) {
+				t.Errorf("got %v, want %v", got, w)
+			}
+		})
+	}
+}
+
+// the days log a lot - only show it with go test -v
+func quiet() {
+	if !testing.Verbose() {
+		log.SetOutput(io.Discard)
+	}
+}
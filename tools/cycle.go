@@ -0,0 +1,120 @@
+/*
+ * Cycle detection
+ *
+ * For "simulate a billion steps" puzzles: find the first state that repeats
+ * (after mu steps) and the length of the cycle (lambda), then fast-forward.
+ * See https://en.wikipedia.org/wiki/Cycle_detection
+ *
+ * The step function must return a new state and must not modify its
+ * argument. All functions loop forever if the states never repeat.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+// Find the cycle via Brent's algorithm - mu is the index of the first
+// state in the cycle, lambda the length of the cycle
+func FindCycle[S any](start S, step func(S) S, eq func(a, b S) bool) (mu, lambda int) {
+	power, lambda := 1, 1
+	tortoise, hare := start, step(start)
+	for !eq(tortoise, hare) {
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = step(hare)
+		lambda++
+	}
+
+	tortoise, hare = start, start
+	for i := 0; i < lambda; i++ {
+		hare = step(hare)
+	}
+	for !eq(tortoise, hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		mu++
+	}
+	return mu, lambda
+}
+
+// Find the cycle via Floyd's tortoise and hare - same result as FindCycle,
+// but usually more steps
+func FindCycleFloyd[S any](start S, step func(S) S, eq func(a, b S) bool) (mu, lambda int) {
+	tortoise, hare := step(start), step(step(start))
+	for !eq(tortoise, hare) {
+		tortoise = step(tortoise)
+		hare = step(step(hare))
+	}
+
+	tortoise = start
+	for !eq(tortoise, hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		mu++
+	}
+
+	lambda = 1
+	hare = step(tortoise)
+	for !eq(tortoise, hare) {
+		hare = step(hare)
+		lambda++
+	}
+	return mu, lambda
+}
+
+// Find the cycle by remembering the key of every state - needs memory for
+// all states up to mu+lambda, but every step is done only once
+func FindCycleKey[S any, K comparable](start S, step func(S) S, key func(S) K) (mu, lambda int) {
+	mu, lambda, _ = walkCycle(start, step, key, -1)
+	return mu, lambda
+}
+
+// The number of steps (less than mu+lambda) leading to the same state as n steps
+func CycleIndex(n, mu, lambda int) int {
+	if n < mu {
+		return n
+	}
+	return mu + (n-mu)%lambda
+}
+
+// State after n steps, fast-forwarded by FindCycle
+func StateAfter[S any](start S, step func(S) S, eq func(a, b S) bool, n int) S {
+	mu, lambda := FindCycle(start, step, eq)
+	state := start
+	for i := CycleIndex(n, mu, lambda); i > 0; i-- {
+		state = step(state)
+	}
+	return state
+}
+
+// State after n steps, fast-forwarded by FindCycleKey
+func StateAfterKey[S any, K comparable](start S, step func(S) S, key func(S) K, n int) S {
+	_, _, state := walkCycle(start, step, key, n)
+	return state
+}
+
+// internally used - step until a key repeats (or n steps are done, if n >= 0)
+// and return the cycle and the state after n steps
+func walkCycle[S any, K comparable](start S, step func(S) S, key func(S) K, n int) (int, int, S) {
+	seen := map[K]int{}
+	states := []S{}
+	state := start
+	for i := 0; ; i++ {
+		if i == n {
+			return 0, 0, state
+		}
+		k := key(state)
+		if first, ok := seen[k]; ok {
+			mu, lambda := first, i-first
+			if n < 0 {
+				return mu, lambda, state
+			}
+			return mu, lambda, states[CycleIndex(n, mu, lambda)]
+		}
+		seen[k] = i
+		states = append(states, state)
+		state = step(state)
+	}
+}
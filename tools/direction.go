@@ -0,0 +1,59 @@
+/*
+ * Direction
+ *
+ * The eight directions on a grid, clockwise starting with North. Positions
+ * are {x, y} with y growing downwards, so North is {0, -1}
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// Horizontal and vertical directions
+var Directions4 = []Direction{North, East, South, West}
+
+// All directions incl. diagonals
+var Directions8 = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+var deltas = [8]Position{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+
+var dirNames = [8]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// Offset of one step into the direction
+func (d Direction) Delta() Position {
+	return deltas[d]
+}
+
+// Turn by 90 degrees counterclockwise
+func (d Direction) TurnLeft() Direction {
+	return (d + 6) % 8
+}
+
+// Turn by 90 degrees clockwise
+func (d Direction) TurnRight() Direction {
+	return (d + 2) % 8
+}
+
+// Opposite direction
+func (d Direction) Reverse() Direction {
+	return (d + 4) % 8
+}
+
+func (d Direction) String() string {
+	if d < 0 || d > NorthWest {
+		return "?"
+	}
+	return dirNames[d]
+}
@@ -0,0 +1,78 @@
+/*
+ * DOT export
+ *
+ * Minimal writer for the Graphviz DOT language, to visualise the structure
+ * of an input, e.g.
+ *
+ *	aoc dot 20 -input real | dot -Tsvg > d20.svg
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Node struct {
+	ID    string
+	Label string // defaults to ID
+	Shape string // e.g. box, ellipse, diamond, doublecircle
+	Color string
+}
+
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// A directed graph
+type Graph struct {
+	Name  string
+	Nodes []Node
+	Edges []Edge
+}
+
+func (g *Graph) AddNode(n Node) {
+	g.Nodes = append(g.Nodes, n)
+}
+
+func (g *Graph) AddEdge(from, to, label string) {
+	g.Edges = append(g.Edges, Edge{from, to, label})
+}
+
+// Write the graph in DOT format
+func (g *Graph) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %v {\n", strconv.Quote(g.Name))
+	for _, n := range g.Nodes {
+		fmt.Fprintf(bw, "  %v%v;\n", strconv.Quote(n.ID), attrs("label", n.Label, "shape", n.Shape, "color", n.Color))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %v -> %v%v;\n", strconv.Quote(e.From), strconv.Quote(e.To), attrs("label", e.Label))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// internally used - attribute list of all non-empty key/value pairs
+func attrs(kv ...string) string {
+	s := ""
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] == "" {
+			continue
+		}
+		if s != "" {
+			s += ", "
+		}
+		s += kv[i] + "=" + strconv.Quote(kv[i+1])
+	}
+	if s == "" {
+		return ""
+	}
+	return " [" + s + "]"
+}
@@ -0,0 +1,31 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	g := Graph{Name: "test"}
+	g.AddNode(Node{ID: "a", Shape: "box"})
+	g.AddNode(Node{ID: `b "x"`, Label: "B", Color: "red"})
+	g.AddNode(Node{ID: "c"})
+	g.AddEdge("a", `b "x"`, "L")
+	g.AddEdge("a", "c", "")
+
+	var sb strings.Builder
+	if err := g.Write(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `digraph "test" {
+  "a" [shape="box"];
+  "b \"x\"" [label="B", color="red"];
+  "c";
+  "a" -> "b \"x\"" [label="L"];
+  "a" -> "c";
+}
+`
+	if sb.String() != want {
+		t.Errorf("got\n%v\nwant\n%v", sb.String(), want)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Graph search
+ *
+ * Shortest paths over arbitrary nodes - positions of a Matrix as well as
+ * state structs (e.g. position + direction + run length). The graph is
+ * never built explicitly, a callback returns the edges leaving a node.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package graph
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Edge to node To with the given (non-negative) cost
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// Callback returning all edges leaving a node
+type Neighbors[N comparable] func(node N) []Edge[N]
+
+// Find the path with the least number of edges from start to the first node
+// for which goal is true - costs of the edges are ignored. Returns the
+// number of edges and the path (incl. start and goal), false if no goal
+// can be reached
+func BFS[N comparable](start N, goal func(N) bool, neighbors Neighbors[N]) (int, []N, bool) {
+	prev := map[N]N{}
+	dist := map[N]int{start: 0}
+	queue := []N{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if goal(node) {
+			return dist[node], path(prev, start, node), true
+		}
+		for _, e := range neighbors(node) {
+			if _, ok := dist[e.To]; !ok {
+				dist[e.To] = dist[node] + 1
+				prev[e.To] = node
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return 0, nil, false
+}
+
+// Number of edges from start to every reachable node (costs are ignored)
+func Distances[N comparable](start N, neighbors Neighbors[N]) map[N]int {
+	dist := map[N]int{start: 0}
+	queue := []N{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, e := range neighbors(node) {
+			if _, ok := dist[e.To]; !ok {
+				dist[e.To] = dist[node] + 1
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return dist
+}
+
+// Find the cheapest path from start to the first node for which goal is
+// true. Returns the total cost and the path (incl. start and goal), false
+// if no goal can be reached
+func Dijkstra[N comparable](start N, goal func(N) bool, neighbors Neighbors[N]) (int, []N, bool) {
+	return AStar(start, goal, neighbors, func(N) int { return 0 })
+}
+
+// Like Dijkstra, but nodes are visited in order of cost + estimate. The
+// estimate must never be larger than the real cost to reach a goal (e.g.
+// the manhattan distance on a grid), otherwise the result may be wrong
+func AStar[N comparable](start N, goal func(N) bool, neighbors Neighbors[N], estimate func(N) int) (int, []N, bool) {
+	prev := map[N]N{}
+	cost := map[N]int{start: 0}
+	pq := &queue[N]{}
+	heap.Push(pq, item[N]{start, 0, estimate(start)})
+	for pq.Len() > 0 {
+		it := heap.Pop(pq).(item[N])
+		if it.cost > cost[it.node] {
+			// already found a cheaper way
+			continue
+		}
+		if goal(it.node) {
+			return it.cost, path(prev, start, it.node), true
+		}
+		for _, e := range neighbors(it.node) {
+			c := it.cost + e.Cost
+			if old, ok := cost[e.To]; !ok || c < old {
+				cost[e.To] = c
+				prev[e.To] = it.node
+				heap.Push(pq, item[N]{e.To, c, c + estimate(e.To)})
+			}
+		}
+	}
+	return 0, nil, false
+}
+
+// internally used - walk back from node to start
+func path[N comparable](prev map[N]N, start, node N) []N {
+	ret := []N{node}
+	for node != start {
+		node = prev[node]
+		ret = append(ret, node)
+	}
+	slices.Reverse(ret)
+	return ret
+}
+
+// internally used - priority queue for container/heap
+type item[N comparable] struct {
+	node N
+	cost int
+	prio int
+}
+
+type queue[N comparable] []item[N]
+
+func (q queue[N]) Len() int           { return len(q) }
+func (q queue[N]) Less(i, j int) bool { return q[i].prio < q[j].prio }
+func (q queue[N]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue[N]) Push(x any)        { *q = append(*q, x.(item[N])) }
+func (q *queue[N]) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
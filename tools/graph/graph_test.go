@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"aoc23/tools"
+	"slices"
+	"testing"
+)
+
+var example = []string{
+	"2413432311323",
+	"3215453535623",
+	"3255245654254",
+	"3446585845452",
+	"4546657867536",
+	"1438598798454",
+	"4457876987766",
+	"3637877979653",
+	"4654967986887",
+	"4564679986453",
+	"1224686865563",
+	"2546548887735",
+	"4322674655533",
+}
+
+func parse(t *testing.T) tools.Grid[int] {
+	g, err := tools.ParseGrid(example, func(r rune) int { return int(r - '0') })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func gridNeighbors(g tools.Grid[int]) Neighbors[tools.Position] {
+	return func(pos tools.Position) []Edge[tools.Position] {
+		edges := []Edge[tools.Position]{}
+		for n := range g.Neighbors4(pos) {
+			edges = append(edges, Edge[tools.Position]{n, g.At(n)})
+		}
+		return edges
+	}
+}
+
+func TestPositions(t *testing.T) {
+	g := parse(t)
+	start, end := tools.Position{0, 0}, tools.Position{g.Cols() - 1, g.Rows() - 1}
+	isEnd := func(p tools.Position) bool { return p == end }
+
+	steps, path, ok := BFS(start, isEnd, gridNeighbors(g))
+	if !ok || steps != 24 || len(path) != 25 || path[0] != start || path[24] != end {
+		t.Errorf("BFS: got %v steps, path %v", steps, path)
+	}
+	if d := Distances(start, gridNeighbors(g)); len(d) != 169 || d[end] != 24 {
+		t.Errorf("Distances: got %v nodes, %v to end", len(d), d[end])
+	}
+
+	cost, path, ok := Dijkstra(start, isEnd, gridNeighbors(g))
+	sum := 0
+	for _, p := range path[1:] {
+		sum += g.At(p)
+	}
+	if !ok || sum != cost {
+		t.Errorf("Dijkstra: got cost %v, path sums up to %v", cost, sum)
+	}
+	acost, _, ok := AStar(start, isEnd, gridNeighbors(g), func(p tools.Position) int { return p.Manhattan(end) })
+	if !ok || acost != cost {
+		t.Errorf("AStar: got cost %v, want %v", acost, cost)
+	}
+
+	if _, _, ok := Dijkstra(start, func(tools.Position) bool { return false }, gridNeighbors(g)); ok {
+		t.Errorf("found unreachable goal")
+	}
+}
+
+// crucible of day 17: at most 3 steps in one direction, no reversing
+type crucible struct {
+	pos tools.Position
+	dir tools.Direction
+	run int
+}
+
+func TestStates(t *testing.T) {
+	g := parse(t)
+	end := tools.Position{g.Cols() - 1, g.Rows() - 1}
+	neighbors := func(c crucible) []Edge[crucible] {
+		edges := []Edge[crucible]{}
+		for _, d := range []tools.Direction{c.dir, c.dir.TurnLeft(), c.dir.TurnRight()} {
+			next := crucible{c.pos.Step(d), d, 1}
+			if d == c.dir {
+				next.run = c.run + 1
+			}
+			if next.run <= 3 && g.InBounds(next.pos) {
+				edges = append(edges, Edge[crucible]{next, g.At(next.pos)})
+			}
+		}
+		return edges
+	}
+	isEnd := func(c crucible) bool { return c.pos == end }
+
+	// start with run 0 facing east, so turning south is possible as well
+	start := crucible{tools.Position{0, 0}, tools.East, 0}
+	cost, path, ok := Dijkstra(start, isEnd, neighbors)
+	if !ok || cost != 102 {
+		t.Errorf("Dijkstra: got %v, want 102", cost)
+	}
+	if !slices.ContainsFunc(path, func(c crucible) bool { return c.run == 3 }) {
+		t.Errorf("expected a run of 3 in %v", path)
+	}
+	acost, _, _ := AStar(start, isEnd, neighbors, func(c crucible) int { return c.pos.Manhattan(end) })
+	if acost != 102 {
+		t.Errorf("AStar: got %v, want 102", acost)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Grid implementation
+ * A rectangular field of arbitrary cells (costs, visited flags, structs...)
+ * backed by a single slice, addressed via Position{x, y}
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+type Grid[T any] struct {
+	cells []T // row by row
+	rows  int
+	cols  int
+}
+
+// Create a grid with all cells set to the zero value of T
+func NewGrid[T any](rows, cols int) Grid[T] {
+	return Grid[T]{make([]T, rows*cols), rows, cols}
+}
+
+// Create a grid from lines, converting every rune with conv. All lines
+// must have the same length
+func ParseGrid[T any](lines []string, conv func(rune) T) (Grid[T], error) {
+	g := Grid[T]{}
+	for i, line := range lines {
+		row := []T{}
+		for _, r := range line {
+			row = append(row, conv(r))
+		}
+		if g.rows > 0 && len(row) != g.cols {
+			return g, &LineError{i + 1, fmt.Errorf("got %v columns, want %v", len(row), g.cols)}
+		}
+		g.AddRow(row)
+	}
+	return g, nil
+}
+
+func (g Grid[T]) Rows() int {
+	return g.rows
+}
+
+func (g Grid[T]) Cols() int {
+	return g.cols
+}
+
+func (g Grid[T]) InBounds(pos Position) bool {
+	return pos[0] >= 0 && pos[0] < g.cols && pos[1] >= 0 && pos[1] < g.rows
+}
+
+// Value at pos - panics if pos is out of bounds
+func (g Grid[T]) At(pos Position) T {
+	return g.cells[g.index(pos)]
+}
+
+// Value at pos, false if pos is out of bounds
+func (g Grid[T]) Get(pos Position) (T, bool) {
+	if !g.InBounds(pos) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[g.index(pos)], true
+}
+
+// Set value at pos, false if pos is out of bounds
+func (g *Grid[T]) Set(pos Position, v T) bool {
+	if !g.InBounds(pos) {
+		return false
+	}
+	g.cells[g.index(pos)] = v
+	return true
+}
+
+// Set all cells to v
+func (g *Grid[T]) Fill(v T) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// Append a row - the first row defines the number of columns, all
+// further rows must have the same length
+func (g *Grid[T]) AddRow(row []T) {
+	if g.rows == 0 {
+		g.cols = len(row)
+	} else if len(row) != g.cols {
+		panic(fmt.Sprintf("row with %v columns added to grid with %v columns", len(row), g.cols))
+	}
+	g.cells = append(g.cells, row...)
+	g.rows++
+}
+
+// Row y as view - changes of the slice change the grid
+func (g Grid[T]) Row(y int) []T {
+	return g.cells[y*g.cols : (y+1)*g.cols : (y+1)*g.cols]
+}
+
+// Column x as copy
+func (g Grid[T]) Col(x int) []T {
+	col := make([]T, g.rows)
+	for y := range col {
+		col[y] = g.cells[y*g.cols+x]
+	}
+	return col
+}
+
+// Iterate over all positions and values, row by row
+func (g Grid[T]) All() iter.Seq2[Position, T] {
+	return func(yield func(Position, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Position{i % g.cols, i / g.cols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Number of cells for which f is true
+func (g Grid[T]) Count(f func(T) bool) int {
+	cnt := 0
+	for _, v := range g.cells {
+		if f(v) {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// Position of the first cell (row by row) for which f is true
+func (g Grid[T]) Find(f func(T) bool) (Position, bool) {
+	i := slices.IndexFunc(g.cells, f)
+	if i < 0 {
+		return Position{-1, -1}, false
+	}
+	return Position{i % g.cols, i / g.cols}, true
+}
+
+// Deep copy of the grid
+func (g Grid[T]) Clone() Grid[T] {
+	return Grid[T]{slices.Clone(g.cells), g.rows, g.cols}
+}
+
+// New grid with rows and columns swapped
+func (g Grid[T]) Transpose() Grid[T] {
+	return g.transform(g.cols, g.rows, func(x, y int) Position { return Position{y, x} })
+}
+
+// New grid rotated by 90 degrees clockwise
+func (g Grid[T]) Rotate90CW() Grid[T] {
+	return g.transform(g.cols, g.rows, func(x, y int) Position { return Position{g.rows - 1 - y, x} })
+}
+
+// New grid rotated by 90 degrees counterclockwise
+func (g Grid[T]) Rotate90CCW() Grid[T] {
+	return g.transform(g.cols, g.rows, func(x, y int) Position { return Position{y, g.cols - 1 - x} })
+}
+
+// New grid mirrored horizontally (left becomes right)
+func (g Grid[T]) FlipH() Grid[T] {
+	return g.transform(g.rows, g.cols, func(x, y int) Position { return Position{g.cols - 1 - x, y} })
+}
+
+// New grid mirrored vertically (top becomes bottom)
+func (g Grid[T]) FlipV() Grid[T] {
+	return g.transform(g.rows, g.cols, func(x, y int) Position { return Position{x, g.rows - 1 - y} })
+}
+
+// Copy of the part with the given size starting at pos (upper left) -
+// panics if it does not fit into the grid
+func (g Grid[T]) SubGrid(pos Position, rows, cols int) Grid[T] {
+	if rows < 0 || cols < 0 || !g.InBounds(pos) || !g.InBounds(pos.Add(Position{cols - 1, rows - 1})) {
+		panic(fmt.Sprintf("sub grid %vx%v at %v out of bounds (%vx%v)", cols, rows, pos, g.cols, g.rows))
+	}
+	ret := NewGrid[T](rows, cols)
+	for y := 0; y < rows; y++ {
+		start := (pos[1]+y)*g.cols + pos[0]
+		copy(ret.Row(y), g.cells[start:start+cols])
+	}
+	return ret
+}
+
+// Iterate over the horizontal and vertical neighbours of pos within the grid
+func (g Grid[T]) Neighbors4(pos Position) iter.Seq[Position] {
+	return g.neighbors(pos, Directions4)
+}
+
+// Iterate over all neighbours of pos (incl. diagonals) within the grid
+func (g Grid[T]) Neighbors8(pos Position) iter.Seq[Position] {
+	return g.neighbors(pos, Directions8)
+}
+
+// internally used
+func (g Grid[T]) neighbors(pos Position, dirs []Direction) iter.Seq[Position] {
+	return func(yield func(Position) bool) {
+		for _, d := range dirs {
+			n := pos.Step(d)
+			if g.InBounds(n) && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Grids are equal if they have the same size and values
+func GridEqual[T comparable](a, b Grid[T]) bool {
+	return a.rows == b.rows && a.cols == b.cols && slices.Equal(a.cells, b.cells)
+}
+
+// internally used - new grid where value at {x, y} is moved to target(x, y)
+func (g Grid[T]) transform(rows, cols int, target func(x, y int) Position) Grid[T] {
+	ret := NewGrid[T](rows, cols)
+	for i, v := range g.cells {
+		ret.cells[ret.index(target(i%g.cols, i/g.cols))] = v
+	}
+	return ret
+}
+
+// internally used
+func (g Grid[T]) index(pos Position) int {
+	if !g.InBounds(pos) {
+		panic(fmt.Sprintf("position %v out of bounds (%vx%v)", pos, g.cols, g.rows))
+	}
+	return pos[1]*g.cols + pos[0]
+}
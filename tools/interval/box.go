@@ -0,0 +1,122 @@
+/*
+ * Boxes
+ *
+ * N-dimensional hyper-rectangles made of one Range per dimension - e.g. the
+ * x, m, a and s ratings of day 19 accepted by a workflow
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package interval
+
+import (
+	"slices"
+	"strings"
+)
+
+// One half-open range per dimension
+type Box []Range
+
+// Box with the same range in all dims dimensions
+func Cube(dims int, r Range) Box {
+	b := make(Box, dims)
+	for i := range b {
+		b[i] = r
+	}
+	return b
+}
+
+// Number of points in the box
+func (b Box) Volume() int {
+	vol := 1
+	for _, r := range b {
+		vol *= r.Len()
+	}
+	return vol
+}
+
+// A box is empty if any of its ranges is empty
+func (b Box) Empty() bool {
+	return slices.ContainsFunc(b, Range.Empty)
+}
+
+func (b Box) Contains(point ...int) bool {
+	if len(point) != len(b) {
+		return false
+	}
+	for i, r := range b {
+		if !r.Contains(point[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Split along dimension dim into the parts below v and from v on - one of
+// them may be empty
+func (b Box) SplitAt(dim, v int) (Box, Box) {
+	lo, hi := slices.Clone(b), slices.Clone(b)
+	lo[dim], hi[dim] = b[dim].SplitAt(v)
+	return lo, hi
+}
+
+// Common part of both boxes (of the same dimension), may be empty
+func (b Box) Intersect(other Box) Box {
+	ret := make(Box, len(b))
+	for i := range b {
+		ret[i] = b[i].Intersect(other[i])
+	}
+	return ret
+}
+
+// Parts of b not in other as disjoint boxes (at most two per dimension)
+func (b Box) Subtract(other Box) []Box {
+	if b.Intersect(other).Empty() {
+		return []Box{slices.Clone(b)}
+	}
+	ret := []Box{}
+	rest := slices.Clone(b)
+	for dim := range b {
+		// cut off the slices below and above other in this dimension
+		below, mid := rest.SplitAt(dim, other[dim].Start)
+		mid, above := mid.SplitAt(dim, other[dim].End)
+		for _, part := range []Box{below, above} {
+			if !part.Empty() {
+				ret = append(ret, part)
+			}
+		}
+		rest = mid
+	}
+	return ret
+}
+
+// Number of points covered by the (possibly overlapping) boxes
+func UnionVolume(boxes []Box) int {
+	disjoint := []Box{}
+	for _, b := range boxes {
+		if b.Empty() {
+			continue
+		}
+		parts := []Box{b}
+		for _, d := range disjoint {
+			next := []Box{}
+			for _, p := range parts {
+				next = append(next, p.Subtract(d)...)
+			}
+			parts = next
+		}
+		disjoint = append(disjoint, parts...)
+	}
+	vol := 0
+	for _, b := range disjoint {
+		vol += b.Volume()
+	}
+	return vol
+}
+
+func (b Box) String() string {
+	parts := make([]string, len(b))
+	for i, r := range b {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, "x")
+}
@@ -0,0 +1,180 @@
+/*
+ * Intervals
+ *
+ * Half-open integer ranges [Start, End) and sets of them - e.g. to map
+ * seed ranges (day 05) or to split rating ranges (day 19)
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package interval
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Half-open range [Start, End), empty if End <= Start
+type Range struct {
+	Start int
+	End   int
+}
+
+// Range of the given length starting at start
+func FromLength(start, length int) Range {
+	return Range{start, start + length}
+}
+
+func (r Range) Len() int {
+	return max(0, r.End-r.Start)
+}
+
+func (r Range) Empty() bool {
+	return r.End <= r.Start
+}
+
+func (r Range) Contains(v int) bool {
+	return r.Start <= v && v < r.End
+}
+
+// Common part of both ranges, may be empty
+func (r Range) Intersect(other Range) Range {
+	return Range{max(r.Start, other.Start), min(r.End, other.End)}
+}
+
+// Parts of r not in other - none, one or two ranges
+func (r Range) Subtract(other Range) []Range {
+	ret := []Range{}
+	if other.Empty() {
+		if !r.Empty() {
+			ret = append(ret, r)
+		}
+		return ret
+	}
+	for _, part := range []Range{{r.Start, min(r.End, other.Start)}, {max(r.Start, other.End), r.End}} {
+		if !part.Empty() {
+			ret = append(ret, part)
+		}
+	}
+	return ret
+}
+
+// Split into [Start, v) and [v, End) - one of them may be empty
+func (r Range) SplitAt(v int) (Range, Range) {
+	v = min(max(v, r.Start), r.End)
+	return Range{r.Start, v}, Range{v, r.End}
+}
+
+// Range moved by d
+func (r Range) Shift(d int) Range {
+	return Range{r.Start + d, r.End + d}
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("[%v,%v)", r.Start, r.End)
+}
+
+// Set of integers made of disjoint ranges. The zero value is the empty set,
+// sets are never modified by the operations
+type RangeSet struct {
+	ranges []Range // sorted, non-empty, neither overlapping nor adjacent
+}
+
+// Set of all values in the given (possibly overlapping) ranges
+func NewSet(ranges ...Range) RangeSet {
+	sorted := slices.DeleteFunc(slices.Clone(ranges), Range.Empty)
+	slices.SortFunc(sorted, func(a, b Range) int { return cmp.Compare(a.Start, b.Start) })
+	merged := []Range{}
+	for _, r := range sorted {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, r.End)
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	return RangeSet{merged}
+}
+
+// The disjoint ranges of the set, sorted
+func (s RangeSet) Ranges() []Range {
+	return slices.Clone(s.ranges)
+}
+
+func (s RangeSet) Empty() bool {
+	return len(s.ranges) == 0
+}
+
+// Number of values in the set
+func (s RangeSet) Total() int {
+	total := 0
+	for _, r := range s.ranges {
+		total += r.Len()
+	}
+	return total
+}
+
+// Smallest value, false if the set is empty
+func (s RangeSet) Min() (int, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.ranges[0].Start, true
+}
+
+func (s RangeSet) Contains(v int) bool {
+	i, found := slices.BinarySearchFunc(s.ranges, v, func(r Range, v int) int { return cmp.Compare(r.Start, v) })
+	return found || (i > 0 && s.ranges[i-1].Contains(v))
+}
+
+func (s RangeSet) Union(other RangeSet) RangeSet {
+	return NewSet(append(slices.Clone(s.ranges), other.ranges...)...)
+}
+
+func (s RangeSet) Intersect(other RangeSet) RangeSet {
+	ret := []Range{}
+	for _, a := range s.ranges {
+		for _, b := range other.ranges {
+			if c := a.Intersect(b); !c.Empty() {
+				ret = append(ret, c)
+			}
+		}
+	}
+	return NewSet(ret...)
+}
+
+// Values of s not in other
+func (s RangeSet) Subtract(other RangeSet) RangeSet {
+	remain := s.ranges
+	for _, b := range other.ranges {
+		next := []Range{}
+		for _, a := range remain {
+			next = append(next, a.Subtract(b)...)
+		}
+		remain = next
+	}
+	return NewSet(remain...)
+}
+
+// Split into the values inside and outside of r
+func (s RangeSet) SplitBy(r Range) (inside, outside RangeSet) {
+	rs := NewSet(r)
+	return s.Intersect(rs), s.Subtract(rs)
+}
+
+// Set with all values moved by d
+func (s RangeSet) Shift(d int) RangeSet {
+	ret := make([]Range, len(s.ranges))
+	for i, r := range s.ranges {
+		ret[i] = r.Shift(d)
+	}
+	return RangeSet{ret}
+}
+
+func (s RangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
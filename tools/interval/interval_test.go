@@ -0,0 +1,150 @@
+package interval
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	r := FromLength(10, 5) // [10,15)
+	if r.Len() != 5 || !r.Contains(10) || r.Contains(15) || r.Empty() {
+		t.Errorf("unexpected range %v", r)
+	}
+	if c := r.Intersect(Range{12, 20}); c != (Range{12, 15}) {
+		t.Errorf("intersect: got %v", c)
+	}
+	if c := r.Intersect(Range{20, 30}); !c.Empty() || c.Len() != 0 {
+		t.Errorf("intersect of disjoint ranges: got %v", c)
+	}
+	if got := r.Subtract(Range{11, 13}); !slices.Equal(got, []Range{{10, 11}, {13, 15}}) {
+		t.Errorf("subtract: got %v", got)
+	}
+	if got := r.Subtract(Range{0, 100}); len(got) != 0 {
+		t.Errorf("subtract all: got %v", got)
+	}
+	// empty ranges, e.g. the intersection of disjoint ranges
+	if got := (Range{0, 20}).Subtract(Range{10, 2}); !slices.Equal(got, []Range{{0, 20}}) {
+		t.Errorf("subtract empty: got %v", got)
+	}
+	if got := r.Subtract(Range{0, 5}.Intersect(Range{7, 9})); !slices.Equal(got, []Range{r}) {
+		t.Errorf("subtract empty intersection: got %v", got)
+	}
+	if got := (Range{5, 5}).Subtract(Range{10, 2}); len(got) != 0 {
+		t.Errorf("subtract from empty: got %v", got)
+	}
+	if lo, hi := r.SplitAt(12); lo != (Range{10, 12}) || hi != (Range{12, 15}) {
+		t.Errorf("split: got %v %v", lo, hi)
+	}
+	if lo, hi := r.SplitAt(100); lo != r || !hi.Empty() {
+		t.Errorf("split outside: got %v %v", lo, hi)
+	}
+	if s := r.Shift(-10); s != (Range{0, 5}) {
+		t.Errorf("shift: got %v", s)
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	s := NewSet(Range{5, 10}, Range{0, 3}, Range{8, 12}, Range{3, 4}, Range{20, 20})
+	if got := s.Ranges(); !slices.Equal(got, []Range{{0, 4}, {5, 12}}) {
+		t.Errorf("new: got %v", got)
+	}
+	if s.Total() != 11 || s.String() != "{[0,4) [5,12)}" {
+		t.Errorf("got total %v, %v", s.Total(), s)
+	}
+	if m, ok := s.Min(); !ok || m != 0 {
+		t.Errorf("min: got %v, %v", m, ok)
+	}
+	for v, want := range map[int]bool{-1: false, 0: true, 3: true, 4: false, 5: true, 11: true, 12: false} {
+		if s.Contains(v) != want {
+			t.Errorf("contains %v: got %v", v, !want)
+		}
+	}
+
+	o := NewSet(Range{2, 6}, Range{10, 15})
+	if got := s.Union(o).Ranges(); !slices.Equal(got, []Range{{0, 15}}) {
+		t.Errorf("union: got %v", got)
+	}
+	if got := s.Intersect(o).Ranges(); !slices.Equal(got, []Range{{2, 4}, {5, 6}, {10, 12}}) {
+		t.Errorf("intersect: got %v", got)
+	}
+	if got := s.Subtract(o).Ranges(); !slices.Equal(got, []Range{{0, 2}, {6, 10}}) {
+		t.Errorf("subtract: got %v", got)
+	}
+	in, out := s.SplitBy(Range{3, 8})
+	if !slices.Equal(in.Ranges(), []Range{{3, 4}, {5, 8}}) || !slices.Equal(out.Ranges(), []Range{{0, 3}, {8, 12}}) {
+		t.Errorf("split: got %v and %v", in, out)
+	}
+	if in.Total()+out.Total() != s.Total() {
+		t.Errorf("split lost values")
+	}
+	if got := s.Shift(100).Ranges(); !slices.Equal(got, []Range{{100, 104}, {105, 112}}) {
+		t.Errorf("shift: got %v", got)
+	}
+
+	var empty RangeSet
+	if _, ok := empty.Min(); ok || !empty.Empty() || empty.Union(s).Total() != s.Total() {
+		t.Errorf("zero value is not an empty set")
+	}
+}
+
+func TestBox(t *testing.T) {
+	b := Cube(3, Range{0, 10})
+	if b.Volume() != 1000 || b.Empty() || !b.Contains(0, 5, 9) || b.Contains(0, 10, 0) || b.Contains(1, 2) {
+		t.Errorf("unexpected box %v", b)
+	}
+	lo, hi := b.SplitAt(1, 4)
+	if lo.Volume() != 400 || hi.Volume() != 600 || b.Volume() != 1000 {
+		t.Errorf("split: got %v and %v, original %v", lo, hi, b)
+	}
+	if lo.String() != "[0,10)x[0,4)x[0,10)" {
+		t.Errorf("got %v", lo)
+	}
+
+	inner := Box{{2, 4}, {2, 4}, {2, 4}}
+	parts := b.Subtract(inner)
+	vol := 0
+	for i, p := range parts {
+		vol += p.Volume()
+		if !p.Intersect(inner).Empty() {
+			t.Errorf("part %v overlaps subtracted box", p)
+		}
+		for _, q := range parts[i+1:] {
+			if !p.Intersect(q).Empty() {
+				t.Errorf("parts %v and %v overlap", p, q)
+			}
+		}
+	}
+	if vol != 1000-8 || len(parts) != 6 {
+		t.Errorf("subtract: got %v parts with volume %v", len(parts), vol)
+	}
+}
+
+func TestUnionVolume(t *testing.T) {
+	boxes := []Box{
+		{{0, 4}, {0, 4}},
+		{{2, 6}, {2, 6}},   // overlaps the first by 2x2
+		{{0, 4}, {0, 4}},   // same as the first
+		{{10, 11}, {0, 0}}, // empty
+	}
+	if got := UnionVolume(boxes); got != 16+16-4 {
+		t.Errorf("got %v, want 28", got)
+	}
+	if got := UnionVolume(nil); got != 0 {
+		t.Errorf("got %v for no boxes", got)
+	}
+}
+
+// comparing starts must not overflow near the int limits
+func TestRangeSetLimits(t *testing.T) {
+	lo, hi := Range{math.MinInt, math.MinInt + 10}, Range{math.MaxInt - 10, math.MaxInt}
+	s := NewSet(hi, lo)
+	if got := s.Ranges(); !slices.Equal(got, []Range{lo, hi}) {
+		t.Errorf("got %v", got)
+	}
+	for v, want := range map[int]bool{math.MinInt: true, math.MinInt + 10: false, 0: false, math.MaxInt - 1: true} {
+		if s.Contains(v) != want {
+			t.Errorf("contains %v: got %v", v, !want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Number theory
+ *
+ * GCD, LCM and friends for combining cycles: if something happens first at
+ * step a1 and then every m1 steps, and something else at a2 every m2 steps,
+ * CRT tells when both happen at the same step - the moduli need not be
+ * coprime. All functions detect overflow instead of silently wrapping.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var (
+	ErrOverflow   = errors.New("integer overflow")
+	ErrNoSolution = errors.New("no solution")
+)
+
+// greatest common divisor (GCD) via Euclidean algorithm
+func GCD(a, b int) int {
+	for b != 0 {
+		t := b
+		b = a % b
+		a = t
+	}
+	return a
+}
+
+// find Least Common Multiple (LCM) via GCD - fails with ErrOverflow if the
+// result does not fit into an int
+func LCM(a, b int, integers ...int) (int, error) {
+	result, err := lcm(a, b)
+	for i := 0; i < len(integers) && err == nil; i++ {
+		result, err = lcm(result, integers[i])
+	}
+	return result, err
+}
+
+// Extended Euclidean algorithm: g = gcd(a, b) >= 0 and x, y with
+// a*x + b*y = g
+func ExtendedGCD(a, b int) (g, x, y int, err error) {
+	if a == math.MinInt || b == math.MinInt {
+		return 0, 0, 0, fmt.Errorf("%w: gcd(%v, %v)", ErrOverflow, a, b)
+	}
+	// the coefficients never exceed |a| and |b|
+	oldR, r := a, b
+	oldS, s := 1, 0
+	oldT, t := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+		oldT, t = t, oldT-q*t
+	}
+	if oldR < 0 {
+		return -oldR, -oldS, -oldT, nil
+	}
+	return oldR, oldS, oldT, nil
+}
+
+// x in [0, m) with a*x ≡ 1 (mod m), m must be positive
+func ModInverse(a, m int) (int, error) {
+	if m <= 0 {
+		return 0, fmt.Errorf("invalid modulus %v", m)
+	}
+	g, x, _, err := ExtendedGCD(mod(a, m), m)
+	if err != nil {
+		return 0, err
+	}
+	if g != 1 {
+		return 0, fmt.Errorf("%w: %v has no inverse modulo %v", ErrNoSolution, a, m)
+	}
+	return mod(x, m), nil
+}
+
+// base^exp mod m for exp >= 0 and m > 0, without overflow
+func ModPow(base, exp, m int) int {
+	if exp < 0 || m <= 0 {
+		panic(fmt.Sprintf("invalid modular power %v^%v mod %v", base, exp, m))
+	}
+	result := 1 % m
+	base = mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+	}
+	return result
+}
+
+// Chinese remainder theorem: smallest x >= 0 with x ≡ remainders[i]
+// (mod moduli[i]) for all i, and the LCM of the moduli - all solutions are
+// x + k*lcm. Fails with ErrNoSolution if the congruences contradict each
+// other (possible for moduli that are not coprime) and with ErrOverflow if
+// the LCM does not fit into an int.
+func CRT(remainders, moduli []int) (int, int, error) {
+	if len(remainders) != len(moduli) {
+		return 0, 0, fmt.Errorf("%v remainders for %v moduli", len(remainders), len(moduli))
+	}
+	x, m := 0, 1
+	for i, mi := range moduli {
+		if mi <= 0 {
+			return 0, 0, fmt.Errorf("invalid modulus %v", mi)
+		}
+		var err error
+		if x, m, err = crt(x, m, mod(remainders[i], mi), mi); err != nil {
+			return 0, 0, err
+		}
+	}
+	return x, m, nil
+}
+
+// integer square root, i.e. the largest r with r*r <= n
+func Sqrt(n int) int {
+	if n < 0 {
+		panic(fmt.Sprintf("square root of negative number %v", n))
+	}
+	// correct rounding errors of float64 for large n (without r*r overflowing)
+	r := int(math.Sqrt(float64(n)))
+	for r > 0 && r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
+
+// internally used
+func lcm(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	if a == math.MinInt || b == math.MinInt {
+		return 0, fmt.Errorf("%w: lcm(%v, %v)", ErrOverflow, a, b)
+	}
+	a, b = abs(a), abs(b)
+	result, ok := mul(a/GCD(a, b), b)
+	if !ok {
+		return 0, fmt.Errorf("%w: lcm(%v, %v)", ErrOverflow, a, b)
+	}
+	return result, nil
+}
+
+// internally used - combine x ≡ a1 (mod m1) and x ≡ a2 (mod m2) with
+// 0 <= a1 < m1 and 0 <= a2 < m2
+func crt(a1, m1, a2, m2 int) (int, int, error) {
+	g, p, _, err := ExtendedGCD(m1, m2) // p*m1 ≡ g (mod m2)
+	if err != nil {
+		return 0, 0, err
+	}
+	diff := a2 - a1
+	if diff%g != 0 {
+		return 0, 0, fmt.Errorf("%w: x ≡ %v (mod %v) and x ≡ %v (mod %v)", ErrNoSolution, a1, m1, a2, m2)
+	}
+	m2g := m2 / g
+	l, ok := mul(m1, m2g)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: lcm(%v, %v)", ErrOverflow, m1, m2)
+	}
+	// x = a1 + k*m1 with k*g ≡ diff (mod m2), so x < m1 + (m2g-1)*m1 = l
+	k := mulMod(mod(diff/g, m2g), mod(p, m2g), m2g)
+	return a1 + k*m1, l, nil
+}
+
+// internally used - a*b and whether it fits into an int
+func mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return c, true
+}
+
+// internally used - a*b mod m for 0 <= a, b < m, falls back to math/big
+// if a*b overflows
+func mulMod(a, b, m int) int {
+	if c, ok := mul(a, b); ok {
+		return c % m
+	}
+	c := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(b)))
+	return int(c.Mod(c, big.NewInt(int64(m))).Int64())
+}
+
+// internally used - remainder in [0, m)
+func mod(a, m int) int {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
@@ -1,20 +1,37 @@
 /*
  * Matrix implementation
- * Allows to store a byte field
+ * Allows to store a byte field (based on the generic Grid)
  *
- * Helper type "Position" to navigate more easy
+ * Helper types "Position" and "Direction" to navigate more easy
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
 package tools
 
 import (
-	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
 	"strings"
 )
 
 type Position [2]int
 
+// Sum of both positions
+func (pos Position) Add(other Position) Position {
+	return Position{pos[0] + other[0], pos[1] + other[1]}
+}
+
+// Neighbour in direction d
+func (pos Position) Step(d Direction) Position {
+	return pos.Add(d.Delta())
+}
+
+// Manhattan distance to other position
+func (pos Position) Manhattan(other Position) int {
+	return abs(pos[0]-other[0]) + abs(pos[1]-other[1])
+}
+
 func (pos Position) IsBelowOf(other Position) bool {
 	return pos[1] == other[1]+1
 }
@@ -31,49 +48,67 @@ func (pos Position) IsRightOf(other Position) bool {
 	return pos[0] == other[0]+1
 }
 
+// A grid of bytes, e.g. the characters of the input
 type Matrix struct {
-	fields [][]byte
-	rows   int
-	cols   int
+	Grid[byte]
 }
 
 func NewMatrix(rows, cols int) Matrix {
-	m := Matrix{}
-	m.rows = rows
-	m.cols = cols
-	m.fields = make([][]byte, m.rows)
-	for i := range m.fields {
-		m.fields[i] = make([]byte, m.cols)
-	}
-	return m
+	return Matrix{NewGrid[byte](rows, cols)}
 }
 
 func (m Matrix) Copy() Matrix {
-	ret := Matrix{}
-	ret.fields = make([][]byte, m.rows)
-	for i := range ret.fields {
-		ret.fields[i] = make([]byte, m.cols)
-		copy(ret.fields[i], m.fields[i])
-	}
-	return ret
+	return Matrix{m.Clone()}
+}
+
+// Create a matrix from lines of the same length
+func ParseMatrix(lines []string) (Matrix, error) {
+	g, err := ParseGrid(lines, func(r rune) byte { return byte(r) })
+	return Matrix{g}, err
+}
+
+// Matrices are equal if they have the same size and values
+func (m Matrix) Equal(other Matrix) bool {
+	return GridEqual(m.Grid, other.Grid)
+}
+
+// Stable hash (FNV-1a) of size and values - equal matrices have equal hashes
+func (m Matrix) Hash() uint64 {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, [2]int64{int64(m.rows), int64(m.cols)})
+	h.Write(m.cells)
+	return h.Sum64()
+}
+
+// String usable as map key - equal matrices have equal keys
+func (m Matrix) Key() string {
+	return fmt.Sprintf("%dx%d:%s", m.cols, m.rows, m.cells)
 }
 
 func (m Matrix) String() string {
 	var b strings.Builder
-	b.Grow(m.rows * m.cols)
+	b.Grow(m.rows * (m.cols + 1))
 	for i := 0; i < m.rows; i++ {
-		b.Write(m.fields[i])
+		b.Write(m.Row(i))
 		b.WriteString("\n")
 	}
 	return b.String()
 }
 
+// All rows as strings
+func (m Matrix) Lines() []string {
+	lines := make([]string, m.rows)
+	for i := range lines {
+		lines[i] = string(m.Row(i))
+	}
+	return lines
+}
+
 func (m Matrix) NonZeroString() string {
 	var b strings.Builder
-	b.Grow(m.rows * m.cols)
+	b.Grow(m.rows * (m.cols + 1))
 	for i := 0; i < m.Rows(); i++ {
-		for j := 0; j < m.Cols(); j++ {
-			v, _ := m.Value(j, i)
+		for _, v := range m.Row(i) {
 			if v == 0 {
 				b.WriteString(".")
 			} else {
@@ -86,66 +121,34 @@ func (m Matrix) NonZeroString() string {
 }
 
 func (m *Matrix) Reset() {
-	m.fields = nil
-	m.rows = 0
-	m.cols = 0
+	m.Grid = Grid[byte]{}
 }
 
 func (m *Matrix) AddLine(s string) {
-	if m.fields == nil {
-		m.fields = make([][]byte, 0)
-	}
-	m.fields = append(m.fields, []byte(s))
-	m.rows++
-	m.cols = len(s)
-}
-
-func (m Matrix) Cols() int {
-	return m.cols
-}
-
-func (m Matrix) Rows() int {
-	return m.rows
+	m.AddRow([]byte(s))
 }
 
 func (m Matrix) SumValues() int {
 	val := 0
-	for i := 0; i < m.rows; i++ {
-		for j := 0; j < m.cols; j++ {
-			val += int(m.fields[i][j])
-		}
+	for _, v := range m.cells {
+		val += int(v)
 	}
 	return val
 }
 
 func (m Matrix) CountNonZero() int {
-	val := 0
-	for i := 0; i < m.rows; i++ {
-		for j := 0; j < m.cols; j++ {
-			if m.fields[i][j] != 0 {
-				val++
-			}
-		}
-	}
-	return val
+	return m.Count(func(v byte) bool { return v != 0 })
 }
 
 func (m *Matrix) FindField(c byte) (Position, bool) {
-	for y := 0; y < m.rows; y++ {
-		x := bytes.IndexByte(m.fields[y], c)
-		if x >= 0 {
-			pos := Position{x, y}
-			return pos, true
-		}
-	}
-	return Position{-1, -1}, false
+	return m.Find(func(v byte) bool { return v == c })
 }
 
 func (m Matrix) Value(x, y int) (byte, bool) {
-	if x < 0 || x >= m.cols || y < 0 || y >= m.rows {
+	if !m.InBounds(Position{x, y}) {
 		return '?', false
 	}
-	return m.fields[y][x], true
+	return m.At(Position{x, y}), true
 }
 
 func (m Matrix) ValueAtPos(pos Position) (byte, bool) {
@@ -153,16 +156,45 @@ func (m Matrix) ValueAtPos(pos Position) (byte, bool) {
 }
 
 func (m *Matrix) SetValue(x, y int, c byte) bool {
-	if x < 0 || x >= m.cols || y < 0 || y >= m.rows {
-		return false
-	}
-
-	m.fields[y][x] = c
-	return true
+	return m.Set(Position{x, y}, c)
 }
 
 func (m *Matrix) SetValueAtPos(pos Position, c byte) bool {
-	return m.SetValue(pos[0], pos[1], c)
+	return m.Set(pos, c)
+}
+
+func (m Matrix) Transpose() Matrix {
+	return Matrix{m.Grid.Transpose()}
+}
+
+func (m Matrix) Rotate90CW() Matrix {
+	return Matrix{m.Grid.Rotate90CW()}
+}
+
+func (m Matrix) Rotate90CCW() Matrix {
+	return Matrix{m.Grid.Rotate90CCW()}
+}
+
+func (m Matrix) FlipH() Matrix {
+	return Matrix{m.Grid.FlipH()}
+}
+
+func (m Matrix) FlipV() Matrix {
+	return Matrix{m.Grid.FlipV()}
+}
+
+func (m Matrix) SubMatrix(pos Position, rows, cols int) Matrix {
+	return Matrix{m.SubGrid(pos, rows, cols)}
+}
+
+// Row y as string
+func (m Matrix) RowString(y int) string {
+	return string(m.Row(y))
+}
+
+// Column x as string
+func (m Matrix) ColString(x int) string {
+	return string(m.Col(x))
 }
 
 func (m Matrix) LeftOf(pos Position) (Position, bool) {
@@ -196,3 +228,11 @@ func (m Matrix) BelowOf(pos Position) (Position, bool) {
 		return pos, false
 	}
 }
+
+// internally used
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,67 @@
+/*
+ * Memoization
+ *
+ * Cache the results of a recursive function, e.g. for counting solvers:
+ *
+ *	fib := tools.Memoize(func(recurse func(int) int, n int) int {
+ *		if n < 2 {
+ *			return n
+ *		}
+ *		return recurse(n-1) + recurse(n-2)
+ *	})
+ *	fib.Get(90)
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import "fmt"
+
+type Memo[K comparable, V any] struct {
+	f      func(recurse func(K) V, k K) V
+	cache  map[K]V
+	hits   int
+	misses int
+}
+
+// Create a memo for f - f has to call recurse instead of itself, so that
+// the recursive calls are cached as well
+func Memoize[K comparable, V any](f func(recurse func(K) V, k K) V) *Memo[K, V] {
+	return &Memo[K, V]{f: f, cache: make(map[K]V)}
+}
+
+// Result of f for k, calculated only if it is not cached yet
+func (m *Memo[K, V]) Get(k K) V {
+	if v, ok := m.cache[k]; ok {
+		m.hits++
+		return v
+	}
+	m.misses++
+	v := m.f(m.Get, k)
+	m.cache[k] = v
+	return v
+}
+
+// Drop all cached values, e.g. when the input of f changes - the
+// statistics are kept
+func (m *Memo[K, V]) Reset() {
+	clear(m.cache)
+}
+
+// Number of cached values
+func (m *Memo[K, V]) Len() int {
+	return len(m.cache)
+}
+
+// Number of cache hits and misses since creation
+func (m *Memo[K, V]) Stats() (hits, misses int) {
+	return m.hits, m.misses
+}
+
+func (m *Memo[K, V]) String() string {
+	rate := 0.0
+	if m.hits+m.misses > 0 {
+		rate = 100 * float64(m.hits) / float64(m.hits+m.misses)
+	}
+	return fmt.Sprintf("%v hits, %v misses (%.1f%% hit rate), %v cached", m.hits, m.misses, rate, len(m.cache))
+}
@@ -0,0 +1,179 @@
+/*
+ * Line parser
+ *
+ * Fill the fields of a struct from a line of input, either by the named
+ * groups of a regexp or by a simple template with {name} placeholders:
+ *
+ *	type node struct {
+ *		From, Left, Right string
+ *	}
+ *	n, err := tools.ParseLine[node]("{from} = ({left}, {right})", line)
+ *
+ * Groups are mapped to the exported field with the same name (ignoring case)
+ * or to the field tagged with `parse:"name"`. Supported field types are
+ * strings, ints, floats, bools and slices of them - slices are filled from
+ * a comma or whitespace separated list.
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Parse line into a T (which must be a struct) according to pattern. The
+// pattern is taken as regexp if it contains named groups ("(?P<name>...)"),
+// otherwise as template where every {name} matches any text and a space
+// matches any amount of whitespace. Templates must match the whole line
+func ParseLine[T any](pattern string, line string) (T, error) {
+	var ret T
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return ret, err
+	}
+	v := reflect.ValueOf(&ret).Elem()
+	if v.Kind() != reflect.Struct {
+		return ret, fmt.Errorf("can not parse into %T, need a struct", ret)
+	}
+	match := re.FindStringSubmatchIndex(line)
+	if match == nil {
+		return ret, fmt.Errorf("%q does not match %q", line, pattern)
+	}
+	for i, name := range re.SubexpNames() {
+		if i == 0 || len(name) == 0 || match[2*i] < 0 {
+			continue
+		}
+		f, err := fieldByName(v, name)
+		if err != nil {
+			return ret, err
+		}
+		if err := setValue(f, line[match[2*i]:match[2*i+1]]); err != nil {
+			return ret, fmt.Errorf("%v: %w", name, err)
+		}
+	}
+	return ret, nil
+}
+
+// Parse all lines with ParseLine - errors are reported as *LineError
+func ParseLines[T any](pattern string, lines []string) ([]T, error) {
+	ret := make([]T, 0, len(lines))
+	for i, line := range lines {
+		v, err := ParseLine[T](pattern, line)
+		if err != nil {
+			return ret, &LineError{i + 1, err}
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+var (
+	patterns      sync.Map // pattern -> *regexp.Regexp
+	rePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+	reTemplateWsp = regexp.MustCompile(` +`)
+	reNamedGroup  = regexp.MustCompile(`\(\?P?<\w+>`)
+)
+
+// internally used - compiled (and cached) regexp of a pattern
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	expr := pattern
+	if !reNamedGroup.MatchString(pattern) {
+		expr = templateToRegexp(pattern)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// internally used
+func templateToRegexp(tmpl string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	last := 0
+	for _, m := range rePlaceholder.FindAllStringSubmatchIndex(tmpl, -1) {
+		sb.WriteString(templateLiteral(tmpl[last:m[0]]))
+		fmt.Fprintf(&sb, `(?P<%v>.*?)`, tmpl[m[2]:m[3]])
+		last = m[1]
+	}
+	sb.WriteString(templateLiteral(tmpl[last:]))
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// internally used
+func templateLiteral(s string) string {
+	return reTemplateWsp.ReplaceAllString(regexp.QuoteMeta(s), `\s+`)
+}
+
+// internally used - field for the group name, by tag or by name
+func fieldByName(v reflect.Value, name string) (reflect.Value, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("parse")
+		if (ok && tag == name) || (!ok && strings.EqualFold(sf.Name, name)) {
+			if !sf.IsExported() {
+				return reflect.Value{}, fmt.Errorf("field %v of %v is not exported", sf.Name, t)
+			}
+			return v.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("no field for %q in %v", name, t)
+}
+
+// internally used - convert s according to the type of v and store it
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		elems := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		slice := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, e := range elems {
+			if err := setValue(slice.Index(i), e); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+ * Queue and Deque implementation
+ * Ring buffers growing as needed, the zero values are empty queues
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import "iter"
+
+// Double-ended queue
+type Deque[T any] struct {
+	buf  []T
+	head int // index of the front element
+	size int
+}
+
+func (d *Deque[T]) PushBack(elem T) {
+	d.grow()
+	d.buf[(d.head+d.size)%len(d.buf)] = elem
+	d.size++
+}
+
+func (d *Deque[T]) PushFront(elem T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = elem
+	d.size++
+}
+
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // do not keep references
+	d.head = (d.head + 1) % len(d.buf)
+	d.size--
+	return v, true
+}
+
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	i := (d.head + d.size - 1) % len(d.buf)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	return v, true
+}
+
+func (d *Deque[T]) Front() (T, bool) {
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+func (d *Deque[T]) Back() (T, bool) {
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[(d.head+d.size-1)%len(d.buf)], true
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// Remove all elements, keeping the allocated memory
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.size = 0
+}
+
+// Iterate from front to back without removing elements
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(d.buf[(d.head+i)%len(d.buf)]) {
+				return
+			}
+		}
+	}
+}
+
+// internally used - double the buffer if it is full
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(8, 2*len(d.buf)))
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf = buf
+	d.head = 0
+}
+
+// First-in-first-out queue
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// Add elem at the end
+func (q *Queue[T]) Push(elem T) {
+	q.d.PushBack(elem)
+}
+
+// Remove and return the first element
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.d.PopFront()
+}
+
+// First element without removing it
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.Front()
+}
+
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+func (q *Queue[T]) Clear() {
+	q.d.Clear()
+}
+
+// Iterate from first to last without removing elements
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.d.All()
+}
@@ -0,0 +1,144 @@
+/*
+ * Sections
+ *
+ * Many inputs consist of several blocks separated by blank lines (e.g. seeds
+ * and maps on day 05, workflows and parts on day 19). A SectionParser
+ * dispatches every block to a handler selected by a regexp on its first line:
+ *
+ *	err := tools.NewSectionParser().
+ *		Header(`^seeds: (.*)$`, readSeeds).
+ *		Header(`^(\w+)-to-(\w+) map:$`, readMap).
+ *		ParseReader(r)
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+)
+
+// Split lines into groups separated by one or more blank lines - blank
+// lines are dropped, empty groups are skipped (same as Blocks)
+func SplitBlocks(lines []string) [][]string {
+	ret := [][]string{}
+	for _, block := range blocks(slices.Values(lines)) {
+		ret = append(ret, block)
+	}
+	return ret
+}
+
+// Handler of a section: match holds the submatches of the section's regexp
+// (match[0] is the matching line), lines the lines of the section and start
+// the line number of lines[0] in the input (counting from 1)
+type SectionHandler func(match []string, lines []string, start int) error
+
+type section struct {
+	re         *regexp.Regexp
+	keepHeader bool
+	handle     SectionHandler
+}
+
+// Parser for inputs made of blank-line separated sections
+type SectionParser struct {
+	sections []section
+}
+
+func NewSectionParser() *SectionParser {
+	return &SectionParser{}
+}
+
+// Handle blocks starting with a header line matching pattern - the handler
+// gets the remaining lines of the block
+func (p *SectionParser) Header(pattern string, handle SectionHandler) *SectionParser {
+	p.sections = append(p.sections, section{regexp.MustCompile(pattern), false, handle})
+	return p
+}
+
+// Handle blocks without header whose first line matches pattern - the
+// handler gets all lines of the block
+func (p *SectionParser) Block(pattern string, handle SectionHandler) *SectionParser {
+	p.sections = append(p.sections, section{regexp.MustCompile(pattern), true, handle})
+	return p
+}
+
+// Dispatch every block to the first section matching it. Blocks without
+// matching section are an error, empty blocks are skipped. Line numbers
+// assume the blocks were separated by a single blank line - use ParseLines
+// or ParseReader to get the exact line numbers of the input.
+func (p *SectionParser) Parse(blocks [][]string) error {
+	starts := make([]int, len(blocks))
+	start := 1
+	for i, block := range blocks {
+		starts[i] = start
+		if len(block) > 0 {
+			start += len(block) + 1
+		}
+	}
+	return p.parse(blocks, starts)
+}
+
+// Split lines into blocks and parse them
+func (p *SectionParser) ParseLines(lines []string) error {
+	found, starts := [][]string{}, []int{}
+	for start, block := range blocks(slices.Values(lines)) {
+		found = append(found, block)
+		starts = append(starts, start)
+	}
+	return p.parse(found, starts)
+}
+
+// Read lines from r and parse them
+func (p *SectionParser) ParseReader(r io.Reader) error {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return err
+	}
+	return p.ParseLines(lines)
+}
+
+// Handler parsing every line of a section with parse and appending the
+// results to dst
+func Each[T any](dst *[]T, parse func(line string) (T, error)) SectionHandler {
+	return func(_ []string, lines []string, start int) error {
+		for i, line := range lines {
+			v, err := parse(line)
+			if err != nil {
+				return &LineError{start + i, err}
+			}
+			*dst = append(*dst, v)
+		}
+		return nil
+	}
+}
+
+// internally used
+func (p *SectionParser) parse(blocks [][]string, starts []int) error {
+	for i, block := range blocks {
+		if len(block) == 0 {
+			continue
+		}
+		if err := p.parseBlock(block, starts[i]); err != nil {
+			return fmt.Errorf("block %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// internally used
+func (p *SectionParser) parseBlock(block []string, start int) error {
+	for _, s := range p.sections {
+		match := s.re.FindStringSubmatch(block[0])
+		if match == nil {
+			continue
+		}
+		if s.keepHeader {
+			return s.handle(match, block, start)
+		}
+		return s.handle(match, block[1:], start+1)
+	}
+	return fmt.Errorf("no section matches %q", block[0])
+}
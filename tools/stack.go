@@ -1,49 +1,63 @@
 /*
  * Stack implementation
- * Nothing to say
- *
- * Helper type "Position" to navigate more easy
+ * Backed by a slice, the zero value is an empty stack
  *
  * MIT License, Copyright (c) 2023 Jonas Rathert
  */
 package tools
 
-type node[T any] struct {
-	value T
-	prev  *node[T]
-}
+import "iter"
 
 type Stack[T any] struct {
-	top  *node[T]
-	size int
+	values []T
 }
 
 func (s *Stack[T]) Push(elem T) {
-	n := node[T]{elem, s.top}
-	s.top = &n
-	s.size++
+	s.values = append(s.values, elem)
 }
 
 func (s *Stack[T]) Pop() (T, bool) {
-	if s.size == 0 {
-		var zero T
+	var zero T
+	if len(s.values) == 0 {
 		return zero, false
 	}
-	n := s.top
-	s.top = n.prev
-	s.size--
-	return n.value, true
+	n := len(s.values) - 1
+	v := s.values[n]
+	s.values[n] = zero // do not keep references
+	s.values = s.values[:n]
+	return v, true
 }
 
 func (s *Stack[T]) Peek() (T, bool) {
-	if s.size == 0 {
+	if len(s.values) == 0 {
 		var zero T
 		return zero, false
 	}
-	n := s.top
-	return n.value, true
+	return s.values[len(s.values)-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.values)
 }
 
-func (s Stack[T]) Size() int {
-	return s.size
+// Same as Len
+func (s *Stack[T]) Size() int {
+	return len(s.values)
+}
+
+// Remove all elements, keeping the allocated memory
+func (s *Stack[T]) Clear() {
+	clear(s.values)
+	s.values = s.values[:0]
+}
+
+// Iterate from top to bottom without removing elements
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.values) - 1; i >= 0; i-- {
+			if !yield(s.values[i]) {
+				return
+			}
+		}
+	}
 }
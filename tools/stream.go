@@ -0,0 +1,94 @@
+/*
+ * Streaming input
+ *
+ * Iterators over the lines or blank-line separated blocks of any reader, so
+ * the same parser works on stdin, files and strings without reading
+ * everything into memory first
+ *
+ * MIT License, Copyright (c) 2023 Jonas Rathert
+ */
+package tools
+
+import (
+	"io"
+	"iter"
+	"strings"
+)
+
+// Iterate over the lines of r. The reader is consumed, so the sequence can
+// only be used once. After the iteration the returned function reports
+// the read error (as *LineError), if any:
+//
+//	lines, errf := tools.Lines(r)
+//	for line := range lines { ... }
+//	if err := errf(); err != nil { ... }
+func Lines(r io.Reader) (iter.Seq[string], func() error) {
+	var err error
+	seq := func(yield func(string) bool) {
+		scanner := NewScanner(r)
+		num := 0
+		for scanner.Scan() {
+			num++
+			if !yield(scanner.Text()) {
+				return
+			}
+		}
+		if e := scanner.Err(); e != nil {
+			err = &LineError{num + 1, e}
+		}
+	}
+	return seq, func() error { return err }
+}
+
+// Iterate over groups of lines separated by one or more blank lines, e.g.
+// the maps of day 05 or the patterns of day 13. Blank lines are not part
+// of any block, empty blocks are skipped. Same usage as Lines
+func Blocks(r io.Reader) (iter.Seq[[]string], func() error) {
+	lines, errf := Lines(r)
+	seq := func(yield func([]string) bool) {
+		for _, block := range blocks(lines) {
+			if !yield(block) {
+				return
+			}
+		}
+	}
+	return seq, errf
+}
+
+// Read all blocks of r - see Blocks
+func ReadBlocks(r io.Reader) ([][]string, error) {
+	blocks, errf := Blocks(r)
+	ret := [][]string{}
+	for b := range blocks {
+		ret = append(ret, b)
+	}
+	return ret, errf()
+}
+
+// internally used - blocks of lines with the number of their first line
+// (counting from 1), see Blocks
+func blocks(lines iter.Seq[string]) iter.Seq2[int, []string] {
+	return func(yield func(int, []string) bool) {
+		block := []string{}
+		num, start := 0, 0
+		for line := range lines {
+			num++
+			if len(strings.TrimSpace(line)) > 0 {
+				if len(block) == 0 {
+					start = num
+				}
+				block = append(block, line)
+				continue
+			}
+			if len(block) > 0 {
+				if !yield(start, block) {
+					return
+				}
+				block = []string{}
+			}
+		}
+		if len(block) > 0 {
+			yield(start, block)
+		}
+	}
+}
@@ -8,9 +8,12 @@
 package tools
 
 import (
+	"aoc23/cache"
 	"bufio"
-	"log"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -34,16 +37,48 @@ func ReverseStr(s string) string {
 	return string(byte_str)
 }
 
-// Convert known integer string to int
+// Convert known integer string to int - returns 0 if it is no integer,
+// use ParseInt or MustAtoi if the input may be malformed
 func Str2Int(s string) int {
 	val, _ := strconv.Atoi(s)
 	return val
 }
 
+// Convert string to int, surrounding whitespace is ignored
+func ParseInt(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
+// Convert string to int - panics with a useful message if it is no integer
+func MustAtoi(s string) int {
+	val, err := ParseInt(s)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Maximum length of an input line incl. line break (bufio.Scanner default is 64KB)
+var MaxLineLength = 1024 * 1024
+
+// Error when reading a specific line of input
+type LineError struct {
+	Line int // starting with 1
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
 // Read input - return array of strings
 // If parameter s is empty or equal to "input.txt"
 // the file "input.txt" is read. Else read string s
-func ReadInput(s string) []string {
+func ReadInput(s string) ([]string, error) {
 	if len(s) == 0 || s == "input.txt" {
 		s = "input.txt"
 		return ReadInputFile(s)
@@ -53,20 +88,43 @@ func ReadInput(s string) []string {
 }
 
 // Read input from file - return array of strings
-func ReadInputFile(fname string) []string {
+// If the file does not exist, but lives in the directory of a day (e.g.
+// "d03/input.txt"), the input of that day is taken from the local cache
+func ReadInputFile(fname string) ([]string, error) {
 	file, err := os.Open(fname)
+	if os.IsNotExist(err) {
+		if cached, ok := cachedInput(fname); ok {
+			file, err = os.Open(cached)
+		}
+	}
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer file.Close()
-	input := bufio.NewScanner(file)
-	return scan(input)
+	lines, err := ReadLines(file)
+	if err != nil {
+		return lines, fmt.Errorf("%v: %w", fname, err)
+	}
+	return lines, nil
 }
 
 // Read input from multiline string - return array of strings
-func ReadInputString(s string) []string {
-	input := bufio.NewScanner(strings.NewReader(s))
-	return scan(input)
+func ReadInputString(s string) ([]string, error) {
+	return ReadLines(strings.NewReader(s))
+}
+
+// Read input from any reader - return array of strings. Errors (e.g. lines
+// longer than MaxLineLength) are reported as *LineError
+func ReadLines(r io.Reader) ([]string, error) {
+	return scan(NewScanner(r))
+}
+
+// Create a line scanner accepting lines up to MaxLineLength
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	// the scanner uses the larger of capacity and max as limit
+	scanner.Buffer(make([]byte, 0, min(4096, MaxLineLength)), MaxLineLength)
+	return scanner
 }
 
 // Read all ints in a string and return their values as []int
@@ -75,48 +133,54 @@ func ReadInts(s string) []int {
 	elems := re.FindAllString(s, -1)
 	values := make([]int, len(elems))
 	for i, val := range elems {
-		values[i] = Str2Int(val)
+		values[i] = MustAtoi(val)
 	}
 	return values
 }
 
-// Read all ints in a string and return their values as []int
+// Read all (signed) ints in a string and return their values as []int
 func ReadSignedInts(s string) []int {
-	re := regexp.MustCompile(`[\+\-0-9]+`)
+	re := regexp.MustCompile(`[\+\-]?[0-9]+`)
 	elems := re.FindAllString(s, -1)
 	values := make([]int, len(elems))
 	for i, val := range elems {
-		values[i] = Str2Int(val)
+		values[i] = MustAtoi(val)
 	}
 	return values
 }
 
-// greatest common divisor (GCD) via Euclidean algorithm
-func GCD(a, b int) int {
-	for b != 0 {
-		t := b
-		b = a % b
-		a = t
+// internally used - path of the cached input of the day the file belongs to
+func cachedInput(fname string) (string, bool) {
+	abs, err := filepath.Abs(fname)
+	if err != nil {
+		return "", false
 	}
-	return a
-}
-
-// find Least Common Multiple (LCM) via GCD
-func LCM(a, b int, integers ...int) int {
-	result := a * b / GCD(a, b)
-
-	for i := 0; i < len(integers); i++ {
-		result = LCM(result, integers[i])
+	dir := filepath.Base(filepath.Dir(abs))
+	if len(dir) != 3 || dir[0] != 'd' {
+		return "", false
 	}
-
-	return result
+	day, err := strconv.Atoi(dir[1:])
+	if err != nil {
+		return "", false
+	}
+	cached, err := cache.Path(day)
+	if err != nil {
+		return "", false
+	}
+	if _, err := os.Stat(cached); err != nil {
+		return "", false
+	}
+	return cached, true
 }
 
 // internally used
-func scan(scanner *bufio.Scanner) []string {
+func scan(scanner *bufio.Scanner) ([]string, error) {
 	lines := []string{}
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 	}
-	return lines
+	if err := scanner.Err(); err != nil {
+		return lines, &LineError{len(lines) + 1, err}
+	}
+	return lines, nil
 }
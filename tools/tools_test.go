@@ -0,0 +1,664 @@
+package tools
+
+import (
+	"bufio"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadLines(t *testing.T) {
+	lines, err := ReadLines(strings.NewReader("a\nbb\n\nccc"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(lines, []string{"a", "bb", "", "ccc"}) {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestReadLinesTooLong(t *testing.T) {
+	defer func(old int) { MaxLineLength = old }(MaxLineLength)
+	MaxLineLength = 10
+
+	input := "short\nshort\n" + strings.Repeat("x", 20) + "\n"
+	lines, err := ReadLines(strings.NewReader(input))
+	var le *LineError
+	if !errors.As(err, &le) || le.Line != 3 || !errors.Is(err, bufio.ErrTooLong) {
+		t.Fatalf("got %v, want ErrTooLong in line 3", err)
+	}
+	if len(lines) != 2 {
+		t.Errorf("got %v lines, want the 2 before the error", len(lines))
+	}
+
+	MaxLineLength = 100
+	if _, err := ReadLines(strings.NewReader(input)); err != nil {
+		t.Errorf("unexpected error with larger buffer: %v", err)
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	if v, err := ParseInt(" 42 "); err != nil || v != 42 {
+		t.Errorf("got %v, %v", v, err)
+	}
+	if _, err := ParseInt("4x2"); err == nil {
+		t.Error("expected error")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustAtoi did not panic")
+		}
+	}()
+	MustAtoi("abc")
+}
+
+func TestReadInts(t *testing.T) {
+	if got := ReadInts("Card 1: 41 48 | 83 86"); !slices.Equal(got, []int{1, 41, 48, 83, 86}) {
+		t.Errorf("ReadInts: got %v", got)
+	}
+	if got := ReadSignedInts("seed-to-soil: 10 -3 +4"); !slices.Equal(got, []int{10, -3, 4}) {
+		t.Errorf("ReadSignedInts: got %v", got)
+	}
+}
+
+func TestLinesStopEarly(t *testing.T) {
+	lines, errf := Lines(strings.NewReader("a\nb\nc\n"))
+	got := []string{}
+	for line := range lines {
+		got = append(got, line)
+		if line == "b" {
+			break
+		}
+	}
+	if err := errf(); err != nil || !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	blocks, err := ReadBlocks(strings.NewReader("\na\nb\n\n\nc\n  \nd\ne"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{{"a", "b"}, {"c"}, {"d", "e"}}
+	if !slices.EqualFunc(blocks, want, slices.Equal) {
+		t.Errorf("got %q, want %q", blocks, want)
+	}
+}
+
+func TestBlocksError(t *testing.T) {
+	defer func(old int) { MaxLineLength = old }(MaxLineLength)
+	MaxLineLength = 10
+
+	_, err := ReadBlocks(strings.NewReader("a\n\n" + strings.Repeat("x", 20)))
+	var le *LineError
+	if !errors.As(err, &le) || le.Line != 3 {
+		t.Errorf("got %v, want error in line 3", err)
+	}
+}
+
+func TestSplitBlocks(t *testing.T) {
+	blocks := SplitBlocks([]string{"", "a", "b", "", "", "c", " ", "d"})
+	want := [][]string{{"a", "b"}, {"c"}, {"d"}}
+	if !slices.EqualFunc(blocks, want, slices.Equal) {
+		t.Errorf("got %q, want %q", blocks, want)
+	}
+}
+
+func TestSectionParser(t *testing.T) {
+	var name string
+	var nums []int
+	var words []string
+	p := NewSectionParser().
+		Header(`^name: (\w+)$`, func(match, lines []string, _ int) error {
+			name = match[1]
+			if len(lines) != 0 {
+				return errors.New("unexpected lines")
+			}
+			return nil
+		}).
+		Header(`^numbers:$`, Each(&nums, ParseInt)).
+		Block(`^[a-z]+$`, Each(&words, func(s string) (string, error) { return s, nil }))
+
+	err := p.ParseReader(strings.NewReader("name: test\n\nnumbers:\n1\n 2\n\nfoo\nbar\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "test" || !slices.Equal(nums, []int{1, 2}) || !slices.Equal(words, []string{"foo", "bar"}) {
+		t.Errorf("got %q, %v, %q", name, nums, words)
+	}
+
+	// line numbers of the input, incl. headers, earlier blocks and blank lines
+	for _, tc := range []struct {
+		input string
+		line  int
+	}{
+		{"numbers:\n1\nx", 3},
+		{"name: test\n\n\nfoo\nbar\n\nnumbers:\n1\nx", 9},
+	} {
+		var le *LineError
+		if err := p.ParseReader(strings.NewReader(tc.input)); !errors.As(err, &le) || le.Line != tc.line {
+			t.Errorf("%q: got %v, want error in line %v", tc.input, err, tc.line)
+		}
+	}
+	var le *LineError
+	if err := p.Parse([][]string{{"foo"}, {"numbers:", "x"}}); !errors.As(err, &le) || le.Line != 4 {
+		t.Errorf("got %v, want error in line 4", err)
+	}
+	if err := p.Parse([][]string{{}, nil}); err != nil {
+		t.Errorf("got %v for empty blocks", err)
+	}
+	if err := p.ParseLines([]string{"???"}); err == nil {
+		t.Errorf("expected error for unknown section")
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	type game struct {
+		ID    int `parse:"game"`
+		Name  string
+		Score float64
+		Nums  []int
+		Tags  []string
+	}
+	g, err := ParseLine[game]("Game {game}: {name}  scored {score} with {nums} [{tags}]", "Game 12: foo bar scored 1.5 with 1, -2,3 [a b,c]")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := game{12, "foo bar", 1.5, []int{1, -2, 3}, []string{"a", "b", "c"}}
+	if g.ID != want.ID || g.Name != want.Name || g.Score != want.Score ||
+		!slices.Equal(g.Nums, want.Nums) || !slices.Equal(g.Tags, want.Tags) {
+		t.Errorf("got %+v, want %+v", g, want)
+	}
+
+	type node struct{ From, Left, Right string }
+	n, err := ParseLine[node](`(?P<from>\w+) = \((?P<left>\w+), (?P<right>\w+)\)`, "AAA = (BBB, CCC)")
+	if err != nil || n != (node{"AAA", "BBB", "CCC"}) {
+		t.Errorf("got %+v, %v", n, err)
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	type pair struct {
+		A     int
+		B     int8
+		other string
+	}
+	for _, tc := range []struct{ pattern, line string }{
+		{"{a} {b}", "1"},       // no match
+		{"{a} {b}", "1 x"},     // no int
+		{"{a} {b}", "1 300"},   // overflow
+		{"{a} {c}", "1 2"},     // no field
+		{"{a} {other}", "1 2"}, // not exported
+		{`(?P<a>\d+`, "1"},     // invalid regexp
+	} {
+		if p, err := ParseLine[pair](tc.pattern, tc.line); err == nil {
+			t.Errorf("%q / %q: expected error, got %+v", tc.pattern, tc.line, p)
+		}
+	}
+	if _, err := ParseLine[int]("{a}", "1"); err == nil {
+		t.Errorf("expected error for non-struct")
+	}
+	_, err := ParseLines[pair]("{a} {b}", []string{"1 2", "3 4", "5"})
+	var le *LineError
+	if !errors.As(err, &le) || le.Line != 3 {
+		t.Errorf("got %v, want error in line 3", err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g, err := ParseGrid([]string{"123", "456"}, func(r rune) int { return int(r - '0') })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Rows() != 2 || g.Cols() != 3 || g.At(Position{2, 1}) != 6 {
+		t.Errorf("got %vx%v grid, value %v", g.Cols(), g.Rows(), g.At(Position{2, 1}))
+	}
+	if _, ok := g.Get(Position{3, 0}); ok || g.InBounds(Position{0, -1}) {
+		t.Errorf("positions out of bounds accepted")
+	}
+	if !slices.Equal(g.Col(1), []int{2, 5}) {
+		t.Errorf("got column %v", g.Col(1))
+	}
+
+	c := g.Clone()
+	g.Row(0)[0] = 9 // views change the grid, but not the clone
+	if g.At(Position{0, 0}) != 9 || c.At(Position{0, 0}) != 1 || GridEqual(g, c) {
+		t.Errorf("row view or clone broken: %v, %v", g.Row(0), c.Row(0))
+	}
+	if !c.Set(Position{0, 0}, 9) || !GridEqual(g, c) {
+		t.Errorf("grids should be equal after set")
+	}
+	if n := g.Count(func(v int) bool { return v > 4 }); n != 3 {
+		t.Errorf("got count %v, want 3", n)
+	}
+	if pos, ok := g.Find(func(v int) bool { return v == 5 }); !ok || pos != (Position{1, 1}) {
+		t.Errorf("got %v, %v", pos, ok)
+	}
+
+	if _, err := ParseGrid([]string{"ab", "c"}, func(r rune) bool { return r == '#' }); err == nil {
+		t.Errorf("expected error for ragged lines")
+	}
+}
+
+func TestDirection(t *testing.T) {
+	for _, d := range Directions8 {
+		if d.TurnLeft().TurnRight() != d || d.Reverse().Reverse() != d ||
+			d.TurnRight().TurnRight() != d.Reverse() {
+			t.Errorf("%v: turning broken", d)
+		}
+		if p := (Position{3, 3}).Step(d).Step(d.Reverse()); p != (Position{3, 3}) {
+			t.Errorf("%v: step and back ends at %v", d, p)
+		}
+	}
+	if North.TurnLeft() != West || West.TurnRight() != North || NorthEast.Reverse() != SouthWest {
+		t.Errorf("unexpected turns")
+	}
+	if p := (Position{1, 1}).Step(North); p != (Position{1, 0}) {
+		t.Errorf("north of {1 1} is %v", p)
+	}
+	if d := (Position{1, 2}).Manhattan(Position{-2, 4}); d != 5 {
+		t.Errorf("got distance %v, want 5", d)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	m := NewMatrix(3, 3)
+	got4 := slices.Collect(m.Neighbors4(Position{0, 0}))
+	if !slices.Equal(got4, []Position{{1, 0}, {0, 1}}) {
+		t.Errorf("got %v", got4)
+	}
+	if n := len(slices.Collect(m.Neighbors8(Position{1, 1}))); n != 8 {
+		t.Errorf("got %v neighbours of center, want 8", n)
+	}
+	if n := len(slices.Collect(m.Neighbors8(Position{2, 1}))); n != 5 {
+		t.Errorf("got %v neighbours at border, want 5", n)
+	}
+}
+
+func TestMatrixTransformations(t *testing.T) {
+	m, err := ParseMatrix([]string{"abc", "def"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		got  Matrix
+		want []string
+	}{
+		{"transpose", m.Transpose(), []string{"ad", "be", "cf"}},
+		{"rotate cw", m.Rotate90CW(), []string{"da", "eb", "fc"}},
+		{"rotate ccw", m.Rotate90CCW(), []string{"cf", "be", "ad"}},
+		{"flip h", m.FlipH(), []string{"cba", "fed"}},
+		{"flip v", m.FlipV(), []string{"def", "abc"}},
+		{"sub", m.SubMatrix(Position{1, 0}, 2, 2), []string{"bc", "ef"}},
+		{"cw+ccw", m.Rotate90CW().Rotate90CCW(), m.Lines()},
+		{"4 x cw", m.Rotate90CW().Rotate90CW().Rotate90CW().Rotate90CW(), m.Lines()},
+	} {
+		if !slices.Equal(tc.got.Lines(), tc.want) {
+			t.Errorf("%v: got %q, want %q", tc.name, tc.got.Lines(), tc.want)
+		}
+	}
+	if m.RowString(1) != "def" || m.ColString(2) != "cf" {
+		t.Errorf("got row %q, col %q", m.RowString(1), m.ColString(2))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for sub matrix out of bounds")
+		}
+	}()
+	m.SubMatrix(Position{2, 0}, 2, 2)
+}
+
+func TestMatrixCopy(t *testing.T) {
+	var m Matrix
+	m.AddLine("ab")
+	m.AddLine("cd")
+	c := m.Copy()
+	c.SetValue(0, 0, 'x')
+	if v, ok := c.Value(1, 1); !ok || v != 'd' || m.String() != "ab\ncd\n" || c.String() != "xb\ncd\n" {
+		t.Errorf("got %q and %q", m, c)
+	}
+}
+
+func TestMatrixEqualHashKey(t *testing.T) {
+	m := NewMatrix(2, 3)
+	m.SetValue(2, 1, '#')
+	c := m.Copy()
+	if c.Rows() != 2 || c.Cols() != 3 {
+		t.Fatalf("copy has size %vx%v, want 3x2", c.Cols(), c.Rows())
+	}
+	if v, ok := c.Value(2, 1); !ok || v != '#' {
+		t.Errorf("copy has value %q, %v", v, ok)
+	}
+	if !m.Equal(c) || m.Hash() != c.Hash() || m.Key() != c.Key() {
+		t.Errorf("copy not equal to original")
+	}
+
+	c.SetValue(0, 0, 'x')
+	if m.Equal(c) || m.Hash() == c.Hash() || m.Key() == c.Key() {
+		t.Errorf("changed copy still equal to original")
+	}
+
+	// same values, different shape
+	r := NewMatrix(3, 2)
+	r.SetValue(1, 2, '#')
+	if m.Equal(r) || m.Hash() == r.Hash() || m.Key() == r.Key() {
+		t.Errorf("matrices of different shape are equal")
+	}
+
+	seen := map[string]int{m.Key(): 1}
+	if seen[m.Copy().Key()] != 1 {
+		t.Errorf("key lookup of copy failed")
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	// 0 1 2 3 4 5 6 7 3 4 ... -> mu 3, lambda 5
+	step := func(x int) int {
+		if x == 7 {
+			return 3
+		}
+		return x + 1
+	}
+	eq := func(a, b int) bool { return a == b }
+	key := func(x int) int { return x }
+
+	for name, find := range map[string]func() (int, int){
+		"brent": func() (int, int) { return FindCycle(0, step, eq) },
+		"floyd": func() (int, int) { return FindCycleFloyd(0, step, eq) },
+		"key":   func() (int, int) { return FindCycleKey(0, step, key) },
+	} {
+		if mu, lambda := find(); mu != 3 || lambda != 5 {
+			t.Errorf("%v: got mu %v, lambda %v, want 3, 5", name, mu, lambda)
+		}
+	}
+
+	for _, n := range []int{0, 2, 7, 8, 1000000000} {
+		want := n
+		if n > 7 {
+			want = 3 + (n-3)%5
+		}
+		if got := StateAfter(0, step, eq, n); got != want {
+			t.Errorf("StateAfter(%v) = %v, want %v", n, got, want)
+		}
+		if got := StateAfterKey(0, step, key, n); got != want {
+			t.Errorf("StateAfterKey(%v) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestStack(t *testing.T) {
+	var s Stack[int]
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{3, 2, 1}) || s.Len() != 3 {
+		t.Errorf("got %v", got)
+	}
+	if v, ok := s.Peek(); !ok || v != 3 {
+		t.Errorf("peek: got %v, %v", v, ok)
+	}
+	if v, ok := s.Pop(); !ok || v != 3 || s.Size() != 2 {
+		t.Errorf("pop: got %v, %v", v, ok)
+	}
+	s.Clear()
+	if _, ok := s.Pop(); ok || s.Len() != 0 {
+		t.Errorf("stack not empty after clear")
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	// wrap around the ring buffer and let it grow several times
+	for i := 0; i < 20; i++ {
+		d.PushBack(i)
+		d.PushFront(-i)
+		if i%3 == 0 {
+			d.PopFront()
+		}
+	}
+	want := []int{}
+	for i := 19; i >= 0; i-- {
+		// the first pop removes the 0 pushed to the front
+		if i%3 != 0 {
+			want = append(want, -i)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		want = append(want, i)
+	}
+	if got := slices.Collect(d.All()); !slices.Equal(got, want) || d.Len() != len(want) {
+		t.Errorf("got %v (len %v), want %v", got, d.Len(), want)
+	}
+	f, _ := d.Front()
+	b, _ := d.Back()
+	if f != -19 || b != 19 {
+		t.Errorf("front %v, back %v", f, b)
+	}
+	if v, ok := d.PopBack(); !ok || v != 19 {
+		t.Errorf("pop back: got %v, %v", v, ok)
+	}
+	d.Clear()
+	if _, ok := d.PopFront(); ok || d.Len() != 0 {
+		t.Errorf("deque not empty after clear")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	var q Queue[string]
+	for _, s := range []string{"a", "b", "c"} {
+		q.Push(s)
+	}
+	if v, ok := q.Pop(); !ok || v != "a" {
+		t.Errorf("pop: got %v, %v", v, ok)
+	}
+	q.Push("d")
+	if got := slices.Collect(q.All()); !slices.Equal(got, []string{"b", "c", "d"}) {
+		t.Errorf("got %q", got)
+	}
+	if v, _ := q.Peek(); v != "b" || q.Len() != 3 {
+		t.Errorf("peek: got %v, len %v", v, q.Len())
+	}
+}
+
+// the former linked list stack - kept for comparison in the benchmarks
+type linkedNode[T any] struct {
+	value T
+	prev  *linkedNode[T]
+}
+
+type linkedStack[T any] struct {
+	top  *linkedNode[T]
+	size int
+}
+
+func (s *linkedStack[T]) Push(elem T) {
+	s.top = &linkedNode[T]{elem, s.top}
+	s.size++
+}
+
+func (s *linkedStack[T]) Pop() (T, bool) {
+	if s.size == 0 {
+		var zero T
+		return zero, false
+	}
+	n := s.top
+	s.top = n.prev
+	s.size--
+	return n.value, true
+}
+
+// push and pop in bursts, like a depth first search does
+func BenchmarkStack(b *testing.B) {
+	var s Stack[*Position]
+	p := &Position{}
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			s.Push(p)
+		}
+		for j := 0; j < 1000; j++ {
+			s.Pop()
+		}
+	}
+}
+
+func BenchmarkLinkedStack(b *testing.B) {
+	var s linkedStack[*Position]
+	p := &Position{}
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			s.Push(p)
+		}
+		for j := 0; j < 1000; j++ {
+			s.Pop()
+		}
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	var q Queue[*Position]
+	p := &Position{}
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			q.Push(p)
+		}
+		for j := 0; j < 1000; j++ {
+			q.Pop()
+		}
+	}
+}
+
+func TestMemo(t *testing.T) {
+	calls := 0
+	fib := Memoize(func(recurse func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return recurse(n-1) + recurse(n-2)
+	})
+	if v := fib.Get(90); v != 2880067194370816120 {
+		t.Errorf("got %v", v)
+	}
+	if calls != 91 || fib.Len() != 91 {
+		t.Errorf("got %v calls, %v cached, want 91", calls, fib.Len())
+	}
+	if hits, misses := fib.Stats(); hits != 88 || misses != 91 {
+		t.Errorf("got %v hits, %v misses", hits, misses)
+	}
+
+	fib.Reset()
+	fib.Get(2)
+	if calls != 94 || fib.Len() != 3 {
+		t.Errorf("got %v calls, %v cached after reset", calls, fib.Len())
+	}
+	if hits, misses := fib.Stats(); hits != 88 || misses != 94 {
+		t.Errorf("got %v hits, %v misses after reset, stats should be kept", hits, misses)
+	}
+}
+
+func TestLCM(t *testing.T) {
+	if v, err := LCM(4, 6, 10); err != nil || v != 60 {
+		t.Errorf("got %v, %v", v, err)
+	}
+	if v, err := LCM(-4, 6); err != nil || v != 12 {
+		t.Errorf("got %v, %v for negative value", v, err)
+	}
+	// a*b overflows, the LCM does not
+	if v, err := LCM(1<<40, 3<<40); err != nil || v != 3<<40 {
+		t.Errorf("got %v, %v", v, err)
+	}
+	for _, vals := range [][]int{{1 << 40, 3 << 20, 5 << 10, 7, 11, 13, 17, 19, 23}, {math.MinInt, 1}} {
+		if _, err := LCM(vals[0], vals[1], vals[2:]...); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%v: got %v, want ErrOverflow", vals, err)
+		}
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, tc := range [][2]int{{240, 46}, {46, 240}, {-240, 46}, {17, 0}, {0, -5}, {math.MaxInt, math.MaxInt - 1}} {
+		a, b := tc[0], tc[1]
+		g, x, y, err := ExtendedGCD(a, b)
+		if err != nil || g != abs(GCD(a, b)) || a*x+b*y != g {
+			t.Errorf("%v, %v: got %v, %v, %v, %v", a, b, g, x, y, err)
+		}
+	}
+	if _, _, _, err := ExtendedGCD(math.MinInt, 2); !errors.Is(err, ErrOverflow) {
+		t.Errorf("got %v, want ErrOverflow", err)
+	}
+}
+
+func TestModular(t *testing.T) {
+	if v, err := ModInverse(3, 11); err != nil || v != 4 {
+		t.Errorf("got %v, %v", v, err)
+	}
+	if v, err := ModInverse(-3, 11); err != nil || v != 7 {
+		t.Errorf("got %v, %v", v, err)
+	}
+	if _, err := ModInverse(4, 6); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("got %v, want ErrNoSolution", err)
+	}
+	if v := ModPow(4, 13, 497); v != 445 {
+		t.Errorf("got %v", v)
+	}
+	// products overflow an int
+	if v := ModPow(2, 100, math.MaxInt); v != 1<<37 {
+		t.Errorf("got %v", v)
+	}
+	if v := ModPow(5, 0, 1); v != 0 {
+		t.Errorf("got %v", v)
+	}
+	for _, args := range [][3]int{{2, -1, 7}, {2, 3, 0}, {2, 3, -7}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v: expected panic", args)
+				}
+			}()
+			ModPow(args[0], args[1], args[2])
+		}()
+	}
+}
+
+func TestCRT(t *testing.T) {
+	for _, tc := range []struct {
+		remainders, moduli []int
+		x, m               int
+	}{
+		{[]int{2, 3, 2}, []int{3, 5, 7}, 23, 105},
+		{[]int{1, 3, 5}, []int{2, 5, 6}, 23, 30}, // not coprime
+		{[]int{0, 0}, []int{4, 6}, 0, 12},
+		{[]int{-1, 13}, []int{10, 7}, 69, 70},
+		{[]int{3}, []int{1 << 62}, 3, 1 << 62},
+		// intermediate products overflow
+		{[]int{1, 2}, []int{math.MaxInt32, 1<<31 + 1}, 2305843008139952129, 4611686018427387903},
+	} {
+		x, m, err := CRT(tc.remainders, tc.moduli)
+		if err != nil || x != tc.x || m != tc.m {
+			t.Errorf("%v mod %v: got %v, %v, %v", tc.remainders, tc.moduli, x, m, err)
+		}
+	}
+	if _, _, err := CRT([]int{1, 2}, []int{4, 6}); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("got %v, want ErrNoSolution", err)
+	}
+	if _, _, err := CRT([]int{0, 0, 0}, []int{1 << 40, 3, 1<<40 - 1}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("got %v, want ErrOverflow", err)
+	}
+	for _, moduli := range [][]int{{0}, {-3}, {3, 4}} {
+		if _, _, err := CRT([]int{1}, moduli); err == nil {
+			t.Errorf("%v: expected error", moduli)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	for n, want := range map[int]int{0: 0, 1: 1, 3: 1, 4: 2, 99: 9, 100: 10, 1<<62 - 1: 1<<31 - 1, math.MaxInt: 3037000499} {
+		if got := Sqrt(n); got != want {
+			t.Errorf("sqrt(%v): got %v, want %v", n, got, want)
+		}
+	}
+}